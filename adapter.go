@@ -0,0 +1,180 @@
+package easybind
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Request a request of any server or protocol, e.g. fasthttp, bound by BindRequest as an http request
+// without the server constructing one. It may also implement RemoteAddr() string, the network address
+// of the client, and Context() context.Context.
+type Request interface {
+	// Method HTTP method, e.g. GET
+	Method() string
+	// URL the path and query parameters
+	URL() *url.URL
+	// Header the headers, cookies included
+	Header() http.Header
+	// Body the body, nil if none, or the error reading it
+	Body() ([]byte, error)
+	// Form the decoded form of the body, nil to decode it from Body by its Content-Type
+	Form() url.Values
+}
+
+type remoteAddrer interface {
+	RemoteAddr() string
+}
+
+type contexter interface {
+	Context() context.Context
+}
+
+// BindRequest binds r into params, as Bind does with an http request.
+//
+//	type fastRequest struct{ ctx *fasthttp.RequestCtx }
+//
+//	func (r fastRequest) Method() string { return string(r.ctx.Method()) }
+//	...
+//	err := easybind.BindRequest(fastRequest{ctx}, &args, pathParams)
+func BindRequest(r Request, params interface{}, pathQueryier ...interface{}) error {
+	return DefaultBinder.BindRequest(r, params, pathQueryier...)
+}
+
+// BindRequest same as BindRequest, by the configuration of b.
+func (b *Binder) BindRequest(r Request, params interface{}, pathQueryier ...interface{}) error {
+	if a, ok := r.(httpAdapter); ok {
+		// read by b, its body limits and timeout included
+		return b.Bind(a.req, params, pathQueryier...)
+	}
+
+	req, err := httpRequest(r)
+	if err != nil {
+		return err
+	}
+
+	return b.Bind(req, params, pathQueryier...)
+}
+
+// httpRequest returns the incoming http request of r.
+func httpRequest(r Request) (*http.Request, error) {
+	ctx := context.Background()
+	if c, ok := r.(contexter); ok {
+		ctx = c.Context()
+	}
+
+	u := r.URL()
+	if u == nil {
+		u = &url.URL{Path: "/"}
+	}
+
+	body, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method(), u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Header = r.Header(); req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Host = req.Header.Get("Host")
+	if len(req.Host) == 0 {
+		req.Host = u.Host
+	}
+	req.RequestURI = u.RequestURI()
+	if a, ok := r.(remoteAddrer); ok {
+		req.RemoteAddr = a.RemoteAddr()
+	}
+
+	if form := r.Form(); form != nil {
+		// decoded by the server, ParseForm keeps it
+		req.PostForm = form
+		req.Form = make(url.Values, len(form))
+		for name, values := range form {
+			req.Form[name] = values
+		}
+		for name, values := range u.Query() {
+			req.Form[name] = append(req.Form[name], values...)
+		}
+	}
+
+	return req, nil
+}
+
+// HTTPRequest returns req as a Request, e.g. to share the code of a Request with net/http servers.
+func HTTPRequest(req *http.Request) Request {
+	return DefaultBinder.HTTPRequest(req)
+}
+
+// HTTPRequest same as HTTPRequest, its body read by the configuration of b: MaxBodySize and Timeout.
+func (b *Binder) HTTPRequest(req *http.Request) Request {
+	return httpAdapter{binder: b, req: req}
+}
+
+type httpAdapter struct {
+	binder *Binder
+	req    *http.Request
+}
+
+func (a httpAdapter) Method() string           { return a.req.Method }
+func (a httpAdapter) URL() *url.URL            { return a.req.URL }
+func (a httpAdapter) Header() http.Header      { return a.req.Header }
+func (a httpAdapter) Form() url.Values         { return nil }
+func (a httpAdapter) RemoteAddr() string       { return a.req.RemoteAddr }
+func (a httpAdapter) Context() context.Context { return a.req.Context() }
+
+// Body reads the body of req, restored for the next handlers.
+func (a httpAdapter) Body() ([]byte, error) {
+	if a.req.Body == nil || a.req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	dl := a.binder.bindDeadline(a.req)
+	data, err := a.binder.readAll(a.req.Body)
+	if timeoutErr := dl.err(); timeoutErr != nil {
+		err = timeoutErr
+	}
+	dl.release()
+	if err != nil {
+		return nil, err
+	}
+
+	restoreBody(a.req, data)
+	return data, nil
+}
+
+// StaticRequest a Request of fixed values, e.g. a test fake or the values copied from the request of another server.
+type StaticRequest struct {
+	Verb     string
+	Target   *url.URL
+	Headers  http.Header
+	Payload  []byte
+	PostForm url.Values
+}
+
+// NewStaticRequest returns a StaticRequest of method to target, e.g. /users/1?fields=name, with body.
+// It panics if target isn't a valid URL.
+func NewStaticRequest(method, target, body string) *StaticRequest {
+	u, err := url.Parse(target)
+	if err != nil {
+		panic(err)
+	}
+
+	r := &StaticRequest{Verb: method, Target: u, Headers: http.Header{}}
+	if len(body) > 0 {
+		r.Payload = []byte(body)
+	}
+
+	return r
+}
+
+func (r *StaticRequest) Method() string        { return r.Verb }
+func (r *StaticRequest) URL() *url.URL         { return r.Target }
+func (r *StaticRequest) Header() http.Header   { return r.Headers }
+func (r *StaticRequest) Body() ([]byte, error) { return r.Payload, nil }
+func (r *StaticRequest) Form() url.Values      { return r.PostForm }
@@ -0,0 +1,72 @@
+package easybind
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type updateDocArgs struct {
+	ID      string `pos:"path:id"`
+	Version int    `pos:"query:version"`
+	Token   string `pos:"header:X-Token"`
+	Title   string `json:"title"`
+	Tag     string `pos:"form:tag"`
+	IP      string `pos:"request:remote_addr"`
+}
+
+type fakeRequest struct {
+	*StaticRequest
+}
+
+func (fakeRequest) RemoteAddr() string { return "10.0.0.1:1234" }
+
+func TestBindRequest(t *testing.T) {
+	r := NewStaticRequest(http.MethodPut, "/docs/1?version=2", `{"title": "t"}`)
+	r.Headers.Set("X-Token", "k")
+	a := updateDocArgs{}
+	assert.Nil(t, BindRequest(fakeRequest{r}, &a, map[string]string{"id": "1"}))
+	assert.Equal(t, updateDocArgs{ID: "1", Version: 2, Token: "k", Title: "t", IP: "10.0.0.1:1234"}, a)
+
+	r = NewStaticRequest(http.MethodPost, "/docs", "")
+	r.PostForm = url.Values{"tag": {"go"}}
+	a = updateDocArgs{}
+	assert.Nil(t, BindRequest(r, &a))
+	assert.Equal(t, "go", a.Tag)
+
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/docs?version=3", strings.NewReader(`{"title": "t"}`))
+	a = updateDocArgs{}
+	assert.Nil(t, BindRequest(HTTPRequest(req), &a))
+	assert.Equal(t, updateDocArgs{Version: 3, Title: "t"}, a)
+	assert.Equal(t, "hello.world", HTTPRequest(req).URL().Host)
+}
+
+type failingRequest struct {
+	*StaticRequest
+}
+
+func (failingRequest) Body() ([]byte, error) { return nil, io.ErrUnexpectedEOF }
+
+func TestRequestBodyError(t *testing.T) {
+	r := NewStaticRequest(http.MethodPut, "/docs/1", "")
+	assert.ErrorIs(t, BindRequest(failingRequest{r}, &updateDocArgs{}), io.ErrUnexpectedEOF)
+
+	b := &Binder{MaxBodySize: 4}
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/docs", strings.NewReader(`{"title": "t"}`))
+	_, err := b.HTTPRequest(req).Body()
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/docs", strings.NewReader(`{"title": "t"}`))
+	assert.ErrorIs(t, b.BindRequest(HTTPRequest(req), &updateDocArgs{}), ErrBodyTooLarge)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/docs", strings.NewReader(`{"title": "t"}`))
+	data, err := HTTPRequest(req).Body()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"title": "t"}`, string(data))
+	data, _ = io.ReadAll(req.Body)
+	assert.Equal(t, `{"title": "t"}`, string(data))
+}
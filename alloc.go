@@ -0,0 +1,54 @@
+package easybind
+
+import "reflect"
+
+// pointedStruct returns the struct type of fieldType, an untagged struct or pointer to struct,
+// through any number of pointers, whose fields may be bound from parameters, e.g. Filter *FilterParams.
+func (r *Registry) pointedStruct(fieldType reflect.StructField) (reflect.Type, bool) {
+	if _, tagged := fieldType.Tag.Lookup(tagNameIn); tagged || fieldType.Anonymous || len(fieldType.PkgPath) > 0 {
+		return nil, false
+	}
+
+	typ := fieldType.Type
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if _, ok := r.TypeBinders[typ]; ok {
+		return nil, false
+	}
+
+	return typ, typ.Kind() == reflect.Struct
+}
+
+// bindPointed binds the fields of field, a struct planned as pointed by pointedStruct. The nil pointers
+// to it are allocated, pruneEmbedded resets them once bound if none of its fields was.
+func (e *easyReq) bindPointed(field reflect.Value, fieldType reflect.StructField, nest *nesting) error {
+	// the json body has the whole struct
+	if nest == nil && len(fieldType.Tag.Get("json")) > 0 {
+		e.mu.Lock()
+		e.hasJSONBody = true
+		e.mu.Unlock()
+	}
+
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			e.allocPointer(field)
+		}
+		field = field.Elem()
+	}
+
+	return e.bindStruct(field, nest.pointed(fieldType.Name))
+}
+
+// pointed returns the nesting of the pointed struct field name, its fields keep their sources
+// unless nested in a struct tagged with the prefix option.
+func (n *nesting) pointed(name string) *nesting {
+	if n == nil {
+		return &nesting{field: name}
+	}
+
+	inner := *n
+	inner.field = n.field + "." + name
+	return &inner
+}
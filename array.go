@@ -0,0 +1,30 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrArrayLength the number of values doesn't match the length of an array field.
+var ErrArrayLength = errors.New("wrong number of values")
+
+// arrayBinder binds values into an array of typ, e.g. [2]float64 from lat=1&lat=2 or lat=1,2:
+// a single value is split by commas. It fails unless there are as many values as the array length.
+func (r *Registry) arrayBinder(values []string, typ reflect.Type) (reflect.Value, error) {
+	if len(values) == 1 && typ.Len() != 1 {
+		values = strings.Split(values[0], ",")
+	}
+
+	if len(values) != typ.Len() {
+		return reflect.Value{}, fmt.Errorf("%w: %d for %s", ErrArrayLength, len(values), typ)
+	}
+
+	arr := reflect.New(typ).Elem()
+	for i, v := range values {
+		arr.Index(i).Set(r.bindValue(strings.TrimSpace(v), typ.Elem()).Convert(typ.Elem()))
+	}
+
+	return arr, nil
+}
@@ -0,0 +1,32 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindArray(t *testing.T) {
+	type nearArgs struct {
+		Point [2]float64 `pos:"query:point"`
+		Tags  [2]string  `pos:"header:X-Tags,split"`
+	}
+	assert.Nil(t, Register(&nearArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/near?point=48.8&point=2.3", nil)
+	req.Header.Set("X-Tags", "a, b")
+	a := nearArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, nearArgs{Point: [2]float64{48.8, 2.3}, Tags: [2]string{"a", "b"}}, a)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/near?point=48.8,%202.3", nil)
+	a = nearArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, [2]float64{48.8, 2.3}, a.Point)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/near?point=1,2,3", nil)
+	err := Bind(req, &nearArgs{})
+	assert.ErrorIs(t, err, ErrArrayLength)
+	assert.EqualError(t, err, `bind Point from query:point "1,2,3": wrong number of values: 3 for [2]float64`)
+}
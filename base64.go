@@ -0,0 +1,37 @@
+package easybind
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+)
+
+// optionBase64 decodes the base64 value before conversion, e.g. `pos:"query:payload,base64"`
+const optionBase64 = "base64"
+
+// isBytes reports whether typ is a []byte, bound from the decoded value of a base64 option as a whole.
+func isBytes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// decodeBase64 decodes values of the standard or the URL encoding, padded or not.
+func decodeBase64(values []string) ([]string, error) {
+	decoded := make([]string, len(values))
+	for i, v := range values {
+		enc := base64.StdEncoding
+		if strings.ContainsAny(v, "-_") {
+			enc = base64.URLEncoding
+		}
+		if !strings.HasSuffix(v, "=") {
+			enc = enc.WithPadding(base64.NoPadding)
+		}
+
+		data, err := enc.DecodeString(v)
+		if err != nil {
+			return nil, err
+		}
+		decoded[i] = string(data)
+	}
+
+	return decoded, nil
+}
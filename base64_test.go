@@ -0,0 +1,37 @@
+package easybind
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookArgs struct {
+	Payload   []byte `pos:"query:payload,base64"`
+	Token     string `pos:"header:X-Token,base64"`
+	Signature []byte `pos:"query:sig,base64"`
+	Count     int    `pos:"query:count,trim,base64"`
+}
+
+func TestBindBase64(t *testing.T) {
+	assert.Nil(t, Register(&webhookArgs{}))
+
+	sig := []byte{0xfb, 0xff, 0x01}
+	query := url.Values{
+		"payload": {base64.StdEncoding.EncodeToString([]byte(`{"id": 1}`))},
+		"sig":     {base64.RawURLEncoding.EncodeToString(sig)},
+		"count":   {" " + base64.StdEncoding.EncodeToString([]byte("42")) + " "},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/hooks?"+query.Encode(), nil)
+	req.Header.Set("X-Token", base64.StdEncoding.EncodeToString([]byte("secret")))
+
+	a := webhookArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, webhookArgs{Payload: []byte(`{"id": 1}`), Token: "secret", Signature: sig, Count: 42}, a)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/hooks?payload=%25%25", nil)
+	assert.NotNil(t, Bind(req, &webhookArgs{}))
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 )
@@ -15,11 +17,16 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // Bind
 const (
-	inTagPath   = "path"
-	inTagQuery  = "query"
-	inTagBody   = "body"
-	inTagForm   = "form"
-	inTagHeader = "header"
+	inTagPath    = "path"
+	inTagQuery   = "query"
+	inTagBody    = "body"
+	inTagForm    = "form"
+	inTagHeader  = "header"
+	inTagRequest = "request"
+	inTagCookie  = "cookie"
+	inTagFlag    = "flag"
+	inTagEnv     = "env"
+	inTagMatrix  = "matrix"
 
 	tagNameIn = "pos"
 	tagSep    = ","
@@ -29,10 +36,38 @@ const (
 // Support Tag `pos`, specified that where we can get this value, only support one
 // - path: from url path, don't support nested struct
 // - query: from url query, don't support nested struct
-// - body: from request's body, default use json, support nested struct
+// - body: from request's body, default use json, support nested struct, a map field tagged `pos:"body"` gets the whole body, `body:/data/name` the value of a JSON Pointer
 // - form: from request form
+// - cookie: from request cookies, signed or encrypted ones are verified by Binder.CookieKeys
+// - request: from the request itself, client_ip, remote_addr, method, host or path, see Binder.ClientIP
+// - flag: from command line flags, -name=value, --name value or -name for a bool, see BindFlags
+// - env: from environment variables, see Binder.LookupEnv and BindEnv
+// - matrix: from the matrix parameters of path segments, role of /users;role=admin/42
+// - custom sources registered by RegisterSource
+// - query, header etc. without name: the parameter named after the field, as derived by Binder.FieldName
+// - query:items, form:items: a slice of structs, an element per index of items.0.sku or items[0][sku]
+// - query:attr, form:attr: a map, by key of attr.color or attr[color], slice values get every value of the key
+// - header:*, header:X-Custom-*, cookie:*, query:filter[*: every header, cookie or query parameter, or those with the prefix, into an http.Header or map field
 // - required: this value is not null
+// - default=8080: the value bound when the parameter is absent, it can't have commas
+// - required_if=Other: required if field Other is set, `|` separates several fields
+// - required_without=Other: required if field Other isn't set, `|` separates several fields
+// - readonly: the client can't set this value, see Binder.DropReadOnly
+// - scope: struct tag `scope:"admin"`, only callers granted the scope by WithScopes can set this value
+// - deprecated, deprecated=old|older: report the parameter, or its old names still bound, to Binder.OnDeprecated
+// - prefix=addr_: a struct field tagged `pos:"query,prefix=addr_"` binds its fields from the parameters with this prefix
+// - factory=circle: an interface field binds as the concrete value made by Factories["circle"]
+// - split: split comma separated lists, e.g. `Accept-Encoding: gzip, br`, into several values
+// - trim, lower, upper, squash: transform the value before conversion, see Transforms
+// - maxsize=10MB, mime=image/png|image/*: a *multipart.FileHeader field tagged form:name gets the file uploaded as name
+//   if not larger than maxsize and its sniffed content of one of the media types, see ErrFileTooLarge and ErrFileType,
+//   a []*multipart.FileHeader all the files uploaded as name, or as any name for form:*,files, at most maxtotal together
+// - func=ParseWindow: parse the value by the registered Funcs instead of the binder of the field type
+// - base64: decode the value, standard or URL encoding, before conversion, a []byte gets the decoded bytes
+// - sanitize=html|control: sanitize the value by Sanitizers, see Binder.Sanitize
+// - sensitive: never show this value in errors or traces, see SensitiveNames
 // pathQueryier get variables from path, GET /api/v1/users/:id , get id
+// params implementing Validator or ContextValidator are validated once bound.
 /*
 type Example struct {
 	ID   string `json:"id"   pos:"path:id"`             // path value default is required
@@ -40,6 +75,133 @@ type Example struct {
 }
 */
 func Bind(req *http.Request, params interface{}, pathQueryier ...interface{}) (err error) {
+	return DefaultBinder.Bind(req, params, pathQueryier...)
+}
+
+// Binder binds requests with its own configuration. The zero value binds without limits.
+type Binder struct {
+	// MaxDepth limits the nesting depth of objects and arrays in a json body, 0 means no limit.
+	MaxDepth int
+	// MaxStringLength limits the length in bytes of every string (keys included) in a json body, 0 means no limit.
+	MaxStringLength int
+	// MaxElements limits the number of members of a single object or array in a json body,
+	// and the rows of a slice of structs bound from indexed parameters, 0 means no limit.
+	MaxElements int
+	// MaxBodySize limits the bytes of the bodies read in memory: json bodies, and those verified by VerifyDigest
+	// or Signature or restored by PreserveBody, failing with ErrBodyTooLarge, 0 means no limit.
+	MaxBodySize int64
+	// Debug traces every bind, a failed bind returns a *TraceError holding the trace.
+	Debug bool
+	// DropReadOnly silently ignores fields tagged readonly set by the client instead of failing with ErrReadOnly.
+	DropReadOnly bool
+	// TrustedProxies IPs or CIDRs of the proxies whose forwarding headers are honored by ClientIP.
+	TrustedProxies []string
+	// CookieKeys keys of cookies tagged signed or encrypted, the first one signs and encrypts, all of them verify and decrypt.
+	CookieKeys [][]byte
+	// Sanitize applied to every parameter value bound to a string field, unless the field selects its own by the sanitize
+	// option. The strings of json bodies are decoded as sent.
+	Sanitize func(string) string
+	// ProfileHeader header selecting the tag profile when the request's context doesn't, e.g. X-API-Version, see WithProfile.
+	ProfileHeader string
+	// DropForbidden silently ignores fields set by a client lacking their scope instead of failing with ErrForbiddenField, see WithScopes.
+	DropForbidden bool
+	// Discriminators decodes interface values of json bodies into the concrete types selected by a property.
+	Discriminators *Discriminators
+	// JSONAPI flattens JSON:API documents, sent as application/vnd.api+json, onto params, see JSONAPIQuery.
+	JSONAPI bool
+	// PreserveBody restores the body of the request once read, so the next handlers can read it again.
+	PreserveBody bool
+	// BodyPolicies how the body of requests is treated by method, e.g. {"GET": BodyIgnored}, see WithBodyPolicy.
+	BodyPolicies map[string]BodyPolicy
+	// StrictContentType rejects bodies whose Content-Type isn't one params is bound from with a *MediaTypeError.
+	StrictContentType bool
+	// OnDeprecated is called when a request sets a parameter tagged deprecated, see AddWarning.
+	OnDeprecated func(req *http.Request, d Deprecation)
+	// HTMLForms binds form fields as browsers submit them: a bool field is true if its checkbox is sent, e.g. on,
+	// false if not, left as is if Merge, the last value wins over a hidden input of the same name; a slice also binds name[].
+	HTMLForms bool
+	// QuerySeparators separate the parameters of queries, & if empty, e.g. "&;" for legacy W3C style queries.
+	QuerySeparators string
+	// ParseQuery parses the raw query of requests, instead of QuerySeparators, e.g. with custom delimiters.
+	ParseQuery func(rawQuery string) (url.Values, error)
+	// LookupEnv looks up the environment variables of env fields, os.LookupEnv if nil.
+	LookupEnv func(key string) (string, bool)
+	// Registry the converters, sources, transforms, sanitizers and factories of b, the package ones if nil.
+	Registry *Registry
+	// MaxMemory bytes of the files of a multipart form kept in memory, 32MB if 0, larger files are spooled
+	// to temporary files. http.Server removes them once the handler of a request it created returns,
+	// those of other requests, e.g. clones made by middlewares, are removed by RemoveFiles or RemoveFilesOnDone.
+	MaxMemory int64
+	// RemoveFilesOnDone removes the spooled files of a request once its context is done: when its handler returns,
+	// but also as soon as the client disconnects, even while the handler still reads them. Don't hand them to work
+	// outliving the handler then.
+	RemoveFilesOnDone bool
+	// VerifyDigest verifies the Content-MD5, Repr-Digest and Content-Digest headers of requests, if sent,
+	// against their body, failing with ErrDigestMismatch.
+	VerifyDigest bool
+	// Signature verifies the HMAC signature header of every request against its body, failing with ErrSignatureMismatch.
+	Signature *Signature
+	// Timeout bounds the binding of a request, the read of its body included, failing with a *TimeoutError, 0 means no limit.
+	// The binding also stops, with a *TimeoutError, once the context of the request is done.
+	Timeout time.Duration
+	// Workers bounds the goroutines binding the fields of a request, one per field if 0. The json body is then read
+	// while the fields and files are bound, and a failed binding doesn't wait for the rest of the body.
+	// Either way, a failed binding reports the first failing field in declaration order, whatever the scheduling.
+	Workers int
+	// FieldName derives the parameter names of fields tagged without one, e.g. `pos:"query,required"`, from their
+	// field names: SnakeCase, CamelCase, KebabCase or a custom convention, the field name itself if nil.
+	FieldName func(field string) string
+	// Merge binds into params as they are, e.g. loaded defaults or a record: only the fields sent by the request
+	// are overwritten, slices replaced rather than appended to, and defaults aren't bound to absent parameters.
+	// Zero values sent, e.g. ?name= or "name": null, are ignored unless MergeZero.
+	Merge bool
+	// MergeZero overwrites the fields of params with the zero values sent by the request when merging, see Merge.
+	MergeZero bool
+}
+
+// DefaultBinder is used by Bind.
+var DefaultBinder = &Binder{
+	MaxDepth:        DefaultMaxDepth,
+	MaxStringLength: DefaultMaxStringLength,
+	MaxElements:     DefaultMaxElements,
+	MaxBodySize:     DefaultMaxBodySize,
+}
+
+// Bind same as Bind, but use b's configuration.
+func (b *Binder) Bind(req *http.Request, params interface{}, pathQueryier ...interface{}) (err error) {
+	var trace *Trace
+	if b.Debug {
+		trace = &Trace{}
+	}
+
+	_, err = b.bind(req, params, pathQueryier, trace)
+	if err != nil && trace != nil {
+		err = &TraceError{Trace: trace, Err: err}
+	}
+
+	return
+}
+
+// bind binds params and returns the fields populated from req.
+func (b *Binder) bind(req *http.Request, params interface{}, pathQueryier []interface{}, trace *Trace) (fields FieldSet, err error) {
+	if targets, ok := params.(Targets); ok {
+		return b.bindTargets(req, targets, pathQueryier, trace)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			fields, err = nil, panicError(r, nil, params)
+		}
+	}()
+
+	dl := b.bindDeadline(req)
+	defer func() {
+		if timeoutErr := dl.err(); timeoutErr != nil {
+			fields, err = nil, timeoutErr
+		}
+		dl.release()
+	}()
+
 	paramsVal := reflect.ValueOf(params)
 	if paramsVal.Kind() != reflect.Ptr {
 		err = errors.New("can't bind to nonpointer value")
@@ -54,134 +216,573 @@ func Bind(req *http.Request, params interface{}, pathQueryier ...interface{}) (e
 		paramsVal = paramsVal.Elem()
 	}
 
+	readBody, err := b.checkBody(req)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case paramsVal.Kind() == reflect.Map || paramsVal.Kind() == reflect.Interface && paramsVal.Type().NumMethod() == 0:
+		if !readBody {
+			return nil, nil
+		}
+		if err = b.checkMediaType(req, mediaTypeJSON); err != nil {
+			return
+		}
+		return nil, b.bindDynamic(req, params)
+	case paramsVal.Kind() == reflect.Interface:
+		if !readBody {
+			return nil, ErrRequired
+		}
+		if err = b.checkMediaType(req, mediaTypeJSON); err != nil {
+			return
+		}
+		return nil, b.bindDiscriminated(req, paramsVal)
+	}
+
 	if paramsVal.Kind() != reflect.Struct {
 		err = errors.New("can't bind to nonstruct value")
 		return
 	}
 
+	query, err := b.query(req)
+	if err != nil {
+		return
+	}
+
+	profile := b.profile(req)
+	if readBody && b.StrictContentType {
+		if err = b.checkMediaType(req, b.registry().structMediaTypes(paramsVal.Type(), profile)...); err != nil {
+			return
+		}
+	}
+
 	var (
-		typ         = paramsVal.Type()
-		wg          = sync.WaitGroup{}
-		ctx, cancel = context.WithCancel(context.Background())
+		ctx, cancel = context.WithCancel(dl.ctx)
 		easy        = &easyReq{
 			ctx:          ctx,
+			binder:       b,
+			registry:     b.registry(),
 			req:          req,
+			query:        query,
+			readBody:     readBody,
 			once:         &sync.Once{},
 			pathQueryier: pathQueryier,
+			trace:        trace,
+			fields:       FieldSet{},
+			profile:      profile,
 		}
+		body *prefetch
 	)
 
 	defer cancel()
+	// copied once, as the fields are bound concurrently with the read of the body and the parse of the form
+	easy.sourceReq = req.WithContext(ctx)
+
+	if b.Workers > 0 {
+		easy.workers = make(chan struct{}, b.Workers)
+		if readBody && req.ContentLength > 0 && contains(b.registry().structMediaTypes(paramsVal.Type(), profile), mediaTypeJSON) {
+			if body = b.prefetchBody(req); body != nil {
+				defer func() {
+					if err != nil {
+						// a failed binding doesn't wait for a slow client to send the body
+						body.abort()
+						return
+					}
+					body.wait()
+				}()
+			}
+		}
+	}
+
+	if err = easy.bindStruct(paramsVal, nil); err != nil {
+		return
+	}
 
-	for i := 0; i < paramsVal.NumField(); i++ {
-		field := paramsVal.Field(i)
-		fieldType := typ.Field(i)
-		wg.Add(1)
-		go func() {
-			err = easy.bindFieldWithCtx(field, fieldType)
-			if err != nil {
-				cancel()
+	if readBody && req.ContentLength > 0 && easy.hasJSONBody {
+		var (
+			data []byte
+			keys map[string]bool
+		)
+
+		if body != nil {
+			if data, err = body.wait(); err == nil && b.PreserveBody {
+				restoreBody(req, data)
 			}
-			wg.Done()
-		}()
+		} else {
+			data, err = b.readBody(req)
+		}
+		if err == nil && b.JSONAPI && isJSONAPI(req) {
+			data, err = b.jsonAPIBody(data)
+		}
+		if err != nil {
+			return
+		}
+
+		if data, err = easy.guardBody(data, paramsVal.Type()); err != nil {
+			return
+		}
+
+		if keys, err = b.decodeJSON(data, params); err != nil {
+			return
+		}
+
+		if err = easy.decodeBodyMaps(data); err != nil {
+			return
+		}
+
+		if err = easy.decodeBodyPointers(data); err != nil {
+			return
+		}
+
+		easy.markBodyFields(paramsVal.Type(), keys)
+	}
+
+	easy.pruneEmbedded()
+	if err = easy.checkRequired(paramsVal.Type(), nil); err != nil {
+		return
 	}
 
-	wg.Wait()
+	if err = easy.checkRows(); err != nil {
+		return
+	}
 
-	if req.ContentLength > 0 && easy.hasJSONBody {
-		err = json.NewDecoder(req.Body).Decode(params)
+	fields = easy.fields
+	if setter, ok := params.(FieldSetter); ok {
+		setter.SetFieldSet(fields)
 	}
 
+	err = validate(req.Context(), params)
 	return
 }
 
 type easyReq struct {
-	ctx          context.Context
-	once         *sync.Once
+	ctx      context.Context
+	binder   *Binder
+	registry *Registry
+	once     *sync.Once
+	// formErr the error parsing the form of the request body, see postForm
+	formErr      error
 	pathQueryier []interface{}
 	req          *http.Request
-	hasJSONBody  bool
+	// sourceReq req with the binding's context, copied before the fields are bound, for custom sources
+	sourceReq   *http.Request
+	query       url.Values
+	readBody    bool
+	hasJSONBody bool
+	trace       *Trace
+	profile     string
+
+	mu     sync.Mutex
+	fields FieldSet
+	// bodyMaps map fields receiving the whole json body
+	bodyMaps []bodyMap
+	// bodyPointers fields receiving the value of a JSON Pointer into the json body
+	bodyPointers []bodyPointer
+	// rows elements of slices of structs bound from indexed parameters
+	rows []nestedType
+	// embedded nil pointers to embedded or nested structs, and interface fields, allocated to bind their fields
+	embedded []reflect.Value
+	// workers bounds the goroutines binding fields if Binder.Workers, nil if unbounded
+	workers chan struct{}
 }
 
-func (e *easyReq) bindFieldWithCtx(field reflect.Value, fieldType reflect.StructField) (err error) {
+// bindStruct binds every field of val concurrently, embedded and nested structs share e.
+// The fields of a nested struct are bound as nest applies them. Every field is bound, the error of the first
+// failing one in declaration order is returned.
+func (e *easyReq) bindStruct(val reflect.Value, nest *nesting) error {
 	var (
-		errCh  = make(chan error, 1)
-		doneCh = make(chan struct{}, 1)
+		p    = e.registry.compile(val.Type())
+		jobs = make([]func() error, 0, len(p.fields))
 	)
-	go func() {
-		e.bindField(field, fieldType, errCh)
-		doneCh <- struct{}{}
+
+	for _, f := range p.fields {
+		field := val.Field(f.index)
+		fieldType := f.fieldType
+		if f.pointed != nil {
+			jobs = append(jobs, func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = panicError(r, &fieldType, nil)
+					}
+				}()
+
+				return e.bindPointed(field, fieldType, nest)
+			})
+			continue
+		}
+
+		if _, embedded := embeddedStruct(fieldType); nest != nil && !embedded {
+			var ok bool
+			if fieldType, ok = nest.apply(e.binder.named(fieldType)); !ok {
+				continue
+			}
+		}
+
+		jobs = append(jobs, func() error {
+			return e.bindFieldWithCtx(field, fieldType, nest)
+		})
+	}
+
+	return e.run(jobs)
+}
+
+// bindFieldWithCtx binds a field unless the binding was canceled by its deadline.
+// It returns once the field is bound, so no goroutine outlives the binding.
+func (e *easyReq) bindFieldWithCtx(field reflect.Value, fieldType reflect.StructField, nest *nesting) (err error) {
+	if e.ctx.Err() != nil {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r, &fieldType, nil)
+		}
 	}()
 
+	errCh := make(chan error, 1)
+	e.bindField(field, fieldType, nest, errCh)
 	select {
-	case <-e.ctx.Done():
-		return
 	case err = <-errCh:
-		return
-	case <-doneCh:
+	default:
 	}
 
 	return
 }
 
-func (e *easyReq) bindField(field reflect.Value, fieldType reflect.StructField, errCh chan error) {
-	if fieldType.Anonymous {
-		r := reflect.New(field.Type())
-		err := Bind(e.req, r.Interface(), e.pathQueryier...)
-		if err != nil {
+func (e *easyReq) bindField(field reflect.Value, fieldType reflect.StructField, nest *nesting, errCh chan error) {
+	// other embedded types bind as any field, e.g. UserID `pos:"path:id"` of type UserID string
+	if _, ok := embeddedStruct(fieldType); ok {
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() && !field.CanSet() {
+				// pointer to an unexported struct type, which can't be allocated
+				return
+			}
+			if field.IsNil() {
+				e.allocPointer(field)
+			}
+			field = field.Elem()
+		}
+
+		if err := e.bindStruct(field, nest); err != nil {
 			errCh <- err
-			return
 		}
-		field.Set(r.Elem())
+		return
+	}
+
+	fieldType = e.binder.named(profiled(fieldType, e.profile))
+	if nested, ok := nestedStruct(fieldType); ok {
+		e.bindNested(field, nested, errCh)
+		return
 	}
 
-	if len(fieldType.Tag.Get("json")) > 0 {
+	if factory, ok := getInTagOption(fieldType, optionFactory); ok && field.Kind() == reflect.Interface {
+		e.bindFactory(field, fieldType, factory, nest, errCh)
+		return
+	}
+
+	// fields of nested structs are bound from their source, the json body has the whole struct
+	if nest == nil && len(fieldType.Tag.Get("json")) > 0 {
+		e.mu.Lock()
 		e.hasJSONBody = true
+		e.mu.Unlock()
 	}
 
 	var (
 		loc, name = getInTagLocAndName(fieldType)
-		values    = make([]string, 0, 1)
+		ft        = FieldTrace{Field: fieldType.Name, Source: loc, Name: name}
 	)
 
+	defer func() {
+		e.trace.add(ft)
+	}()
+
+	if loc == inTagForm {
+		// a form failing to parse binds no field from it, rather than empty ones
+		if _, err := e.postForm(); err != nil {
+			ft.Skipped = err.Error()
+			errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Err: err}
+			return
+		}
+	}
+
+	if loc == inTagForm && isFile(field.Type()) {
+		if err := e.bindFile(field, fieldType, loc, name, &ft); err != nil {
+			errCh <- err
+		}
+		return
+	}
+
+	if isWildcard(name) {
+		e.bindWildcard(field, fieldType, loc, name, &ft)
+		return
+	}
+
+	if loc == inTagQuery && field.Type() == filterConditionsType {
+		if err := e.bindFilter(field, fieldType, loc, name, &ft); err != nil {
+			errCh <- err
+		}
+		return
+	}
+
+	if (loc == inTagQuery || loc == inTagForm) && e.registry.isStructSlice(fieldType.Type) && !hasFunc(fieldType) {
+		if err := e.bindRows(field, fieldType, loc, name, &ft); err != nil {
+			errCh <- err
+		}
+		return
+	}
+
+	if loc == inTagQuery && field.Type() == sparseFieldsType {
+		if err := e.bindSparseFields(field, fieldType, loc, name, &ft); err != nil {
+			errCh <- err
+		}
+		return
+	}
+
+	if (loc == inTagQuery || loc == inTagForm) && e.registry.isParamMap(fieldType.Type) && !hasFunc(fieldType) {
+		e.bindParamMap(field, fieldType, loc, name, &ft)
+		return
+	}
+
+	if loc == inTagBody {
+		ft.Conversion = "json"
+		switch {
+		case isBodyMap(fieldType):
+			e.addBodyMap(field, fieldType)
+			ft.Conversion = "json object"
+		case isBodyPointer(name):
+			e.addBodyPointer(field, fieldType, name)
+		}
+		return
+	}
+
+	values, ok, err := e.sourceValues(fieldType, loc, name)
+	switch {
+	case !ok:
+		ft.Skipped = "malformed pos tag"
+		return
+	case err != nil:
+		ft.Skipped = err.Error()
+		errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Err: err}
+		return
+	}
+
+	if len(values) == 0 {
+		if old, oldValues := e.deprecatedValues(fieldType, loc); len(oldValues) > 0 {
+			ft.Name, name, values = old, old, oldValues
+		}
+	}
+
+	if loc == inTagForm && e.binder.HTMLForms {
+		var unchecked bool
+		if values, unchecked = e.htmlFormValues(field, name, values); unchecked {
+			ft.Skipped = "unchecked"
+			if !e.binder.Merge {
+				// an absent checkbox can't tell unchecked from not sent, merging keeps the field
+				field.SetBool(false)
+			}
+			return
+		}
+	}
+
+	if def, ok := getInTagOption(fieldType, optionDefault); ok && len(values) == 0 && !e.binder.Merge {
+		values = []string{def}
+	}
+
+	if len(values) > 0 {
+		e.checkDeprecated(fieldType, loc, name)
+	}
+
+	if hasInTagOption(fieldType, optionSplit) {
+		values = splitList(values)
+	}
+
+	values = e.registry.transformValues(fieldType, values)
+	if hasInTagOption(fieldType, optionBase64) {
+		var err error
+		if values, err = decodeBase64(values); err != nil {
+			ft.Skipped = err.Error()
+			errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Err: err}
+			return
+		}
+	}
+
+	values = e.sanitizeValues(fieldType, values)
+	ft.Raw = values
+	if isSensitive(fieldType, name) {
+		ft.Raw = maskValues(values)
+	}
+
+	if drop, guardErr := e.guard(fieldType); len(values) > 0 && guardErr != nil {
+		if drop {
+			ft.Skipped = guardErr.Error()
+			return
+		}
+
+		errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: strings.Join(ft.Raw, tagSep), Err: guardErr}
+		return
+	}
+
+	var (
+		reflectVal reflect.Value
+		_, hasType = e.registry.TypeBinders[field.Type()]
+		fn, hasFn  = getInTagOption(fieldType, optionFunc)
+	)
+
+	switch {
+	case len(values) == 0:
+		ft.Skipped = "no value"
+		return
+	case hasFn:
+		ft.Conversion = optionFunc + " " + fn
+
+		var err error
+		if reflectVal, err = e.registry.funcValue(fn, values, field.Type()); err != nil {
+			ft.Skipped = err.Error()
+			errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: strings.Join(ft.Raw, tagSep), Err: err}
+			return
+		}
+	case isBytes(field.Type()) && !hasType && hasInTagOption(fieldType, optionBase64):
+		ft.Conversion = "base64"
+		reflectVal = reflect.ValueOf([]byte(values[0]))
+	case field.Kind() == reflect.Slice && !hasType:
+		if elem := e.registry.describeBinder(field.Type().Elem()); len(elem) > 0 {
+			ft.Conversion = "slice of " + elem
+		}
+		reflectVal = e.registry.sliceValue(values, field.Type())
+	case field.Kind() == reflect.Array && !hasType:
+		if elem := e.registry.describeBinder(field.Type().Elem()); len(elem) > 0 {
+			ft.Conversion = "array of " + elem
+		}
+
+		var err error
+		if reflectVal, err = e.registry.arrayBinder(values, field.Type()); err != nil {
+			ft.Skipped = err.Error()
+			errCh <- &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: strings.Join(ft.Raw, tagSep), Err: err}
+			return
+		}
+	case field.Kind() == reflect.Slice:
+		// a slice type with its own binder parses every value as one list, e.g. ETags
+		ft.Conversion = e.registry.describeBinder(field.Type())
+		reflectVal = e.registry.bindValue(strings.Join(values, tagSep), field.Type())
+	default:
+		ft.Conversion = e.registry.describeBinder(field.Type())
+		reflectVal = e.registry.bindValue(values[0], field.Type())
+	}
+
+	if len(ft.Conversion) == 0 {
+		ft.Skipped = "no binder for " + field.Type().String()
+		return
+	}
+
+	if !reflectVal.Type().ConvertibleTo(field.Type()) {
+		ft.Skipped = "can't convert " + reflectVal.Type().String() + " to " + field.Type().String()
+		return
+	}
+
+	if e.binder.Merge && !e.binder.MergeZero && reflectVal.IsZero() {
+		ft.Skipped = "zero value"
+		return
+	}
+
+	e.setField(fieldType.Name, true)
+
+	if reflectVal.Type() == field.Type() {
+		if field.Type().Kind() == reflect.Slice && !e.binder.Merge {
+			field.Set(reflect.AppendSlice(field, reflectVal))
+		} else {
+			field.Set(reflectVal)
+		}
+	} else {
+		field.Set(reflectVal.Convert(field.Type()))
+	}
+}
+
+// sourceValues returns the values of name in the source loc, ok is false if loc isn't a source.
+func (e *easyReq) sourceValues(fieldType reflect.StructField, loc, name string) (values []string, ok bool, err error) {
 	switch loc {
 	case inTagPath:
-		pathVal := getValueFromPath(name, e.pathQueryier...)
-		values = append(values, pathVal)
+		if pathVal := getValueFromPath(name, e.pathQueryier...); len(pathVal) > 0 {
+			values = append(values, pathVal)
+		}
 	case inTagQuery:
-		values = e.req.URL.Query()[name]
+		values = e.query[name]
 	case inTagHeader:
 		values = e.req.Header.Values(name)
 	case inTagForm:
-		e.once.Do(func() {
-			e.req.ParseForm()
-		})
+		form, _ := e.postForm()
+		values = form[name]
+	case inTagCookie:
+		values, err = e.cookieValues(name, hasInTagOption(fieldType, optionSigned), hasInTagOption(fieldType, optionEncrypted))
+	case inTagRequest:
+		if v := e.requestValue(name); len(v) > 0 {
+			values = append(values, v)
+		}
+	case inTagFlag:
+		values = flagValues(e.req)[name]
+	case inTagMatrix:
+		values = matrixValues(e.req.URL.EscapedPath(), name)
+	case inTagEnv:
+		if v, ok := e.binder.lookupEnv(name); ok {
+			values = append(values, v)
+		}
+	default:
+		src, custom := e.registry.lookupSource(loc)
+		if !custom {
+			return nil, false, nil
+		}
 
-		values = e.req.PostForm[name]
+		// the request of the binding's context, so slow sources give up with it
+		values, err = src.Values(e.sourceReq, name)
 	}
 
-	var reflectVal reflect.Value
-	switch len(values) {
-	case 0:
-		return
-	case 1:
-		reflectVal = BindValue(values[0], field.Type())
-	default:
-		reflectVal = sliceBinder(values, field.Type())
+	return values, true, err
+}
+
+// Tag pos tag of a field, for tools describing params structs.
+type Tag struct {
+	// Source path, query, header, form, cookie, request or body, empty if the tag is malformed
+	Source string
+	// Name parameter name in Source, the field name for body
+	Name string
+	// Options e.g. required, sensitive, required_if=Other
+	Options []string
+}
+
+// ParseTag parses the pos tag of fieldType.
+func ParseTag(fieldType reflect.StructField) Tag {
+	loc, name := getInTagLocAndName(fieldType)
+	splits := strings.Split(fieldType.Tag.Get(tagNameIn), tagSep)
+	options := make([]string, 0, len(splits)-1)
+	for _, s := range splits[1:] {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			options = append(options, s)
+		}
 	}
 
-	if reflectVal.Type().ConvertibleTo(field.Type()) {
-		if reflectVal.Type() == field.Type() {
-			if field.Type().Kind() == reflect.Array || field.Type().Kind() == reflect.Slice {
-				field.Set(reflect.AppendSlice(field, reflectVal))
-			} else {
-				field.Set(reflectVal)
-			}
-		} else {
-			field.Set(reflectVal.Convert(field.Type()))
+	return Tag{Source: loc, Name: name, Options: options}
+}
+
+// Has reports whether the tag has option.
+func (t Tag) Has(option string) bool {
+	for _, o := range t.Options {
+		if o == option {
+			return true
 		}
 	}
 
+	return false
+}
+
+// Get returns the value of a `key=value` option.
+func (t Tag) Get(key string) (value string, ok bool) {
+	for _, o := range t.Options {
+		if kv := strings.SplitN(o, "=", 2); len(kv) == 2 && kv[0] == key {
+			return kv[1], true
+		}
+	}
+
+	return "", false
 }
 
 func getInTagLocAndName(fieldType reflect.StructField) (loc, name string) {
@@ -193,7 +794,26 @@ func getInTagLocAndName(fieldType reflect.StructField) (loc, name string) {
 	}
 
 	splits := strings.Split(inTag, tagSep)
+	if splits[0] == "" || splits[0] == inTagBody {
+		loc = inTagBody
+		name = fieldType.Name
+		return
+	}
+
 	locs := strings.Split(splits[0], ":")
+	if _, nested := getInTagOption(fieldType, optionPrefix); len(locs) == 1 && nested {
+		// nested struct, its fields have the names
+		loc = locs[0]
+		return
+	}
+
+	if len(locs) == 1 && len(strings.TrimSpace(locs[0])) > 0 {
+		// no name, the field name unless derived by Binder.FieldName
+		loc = strings.TrimSpace(locs[0])
+		name = fieldName(fieldType)
+		return
+	}
+
 	if len(locs) != 2 {
 		return
 	}
@@ -204,6 +824,31 @@ func getInTagLocAndName(fieldType reflect.StructField) (loc, name string) {
 	return
 }
 
+// hasInTagOption reports whether option follows the location in the pos tag, e.g. `pos:"query:token,sensitive"`.
+func hasInTagOption(fieldType reflect.StructField, option string) bool {
+	splits := strings.Split(fieldType.Tag.Get(tagNameIn), tagSep)
+	for _, s := range splits[1:] {
+		if strings.TrimSpace(s) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getInTagOption returns the value of a `key=value` option in the pos tag, e.g. `pos:"query:end,required_if=Start"`.
+func getInTagOption(fieldType reflect.StructField, key string) (value string, ok bool) {
+	splits := strings.Split(fieldType.Tag.Get(tagNameIn), tagSep)
+	for _, s := range splits[1:] {
+		kv := strings.SplitN(strings.TrimSpace(s), "=", 2)
+		if len(kv) == 2 && kv[0] == key {
+			return kv[1], true
+		}
+	}
+
+	return "", false
+}
+
 type giner interface {
 	Param(string) string
 }
@@ -212,6 +857,11 @@ type httprouter interface {
 	ByName(string) string
 }
 
+// pathValuer *http.Request of the patterns of http.ServeMux, since Go 1.22
+type pathValuer interface {
+	PathValue(string) string
+}
+
 func getValueFromPath(name string, pathQueryier ...interface{}) string {
 	if len(pathQueryier) == 0 {
 		return ""
@@ -225,5 +875,14 @@ func getValueFromPath(name string, pathQueryier ...interface{}) string {
 		return h.ByName(name)
 	}
 
+	if p, ok := pathQueryier[0].(pathValuer); ok {
+		return p.PathValue(name)
+	}
+
+	// path parameters of a grpc-gateway pattern
+	if m, ok := pathQueryier[0].(map[string]string); ok {
+		return m[name]
+	}
+
 	return ""
 }
@@ -64,3 +64,176 @@ func TestBindGet(t *testing.T) {
 	assert.Equal(t, Status("active"), *args.Status)
 	fmt.Printf("===== %#v \n", args)
 }
+
+func TestBindTrace(t *testing.T) {
+	type args struct {
+		Status *Status `pos:"query:status"`
+		Page   int     `pos:"query:page"`
+		Sort   string  `pos:":sort"`
+		Age    int     `json:"age"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?status=active", nil)
+	trace, err := BindTrace(req, &args{})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(trace.Fields))
+
+	f, ok := trace.Field("Status")
+	assert.True(t, ok)
+	assert.Equal(t, FieldTrace{Field: "Status", Source: "query", Name: "status", Raw: []string{"active"}, Conversion: "pointer to string"}, f)
+
+	f, _ = trace.Field("Page")
+	assert.Equal(t, "no value", f.Skipped)
+
+	f, _ = trace.Field("Sort")
+	assert.Equal(t, "malformed pos tag", f.Skipped)
+
+	f, _ = trace.Field("Age")
+	assert.Equal(t, "body", f.Source)
+	assert.Equal(t, "json", f.Conversion)
+}
+
+func TestBindTraceMaskSensitive(t *testing.T) {
+	type args struct {
+		Auth    string `pos:"header:Authorization"`
+		Code    string `pos:"query:code,sensitive"`
+		Keyword string `pos:"query:keyword"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?code=1234&keyword=go", nil)
+	req.Header.Set("Authorization", "Bearer xyz")
+
+	a := args{}
+	trace, err := BindTrace(req, &a)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer xyz", a.Auth)
+	assert.Equal(t, "1234", a.Code)
+	assert.NotContains(t, trace.String(), "xyz")
+	assert.NotContains(t, trace.String(), "1234")
+	assert.Contains(t, trace.String(), "go")
+}
+
+func TestBindProfile(t *testing.T) {
+	type args struct {
+		Name string `pos:"query:name" pos.v2:"query:user_name"`
+		Age  int    `pos:"query:age"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?name=bob&user_name=alice&age=3", nil)
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, args{Name: "bob", Age: 3}, a)
+
+	a = args{}
+	assert.Nil(t, Bind(req.WithContext(WithProfile(req.Context(), "v2")), &a))
+	assert.Equal(t, args{Name: "alice", Age: 3}, a)
+
+	a = args{}
+	req.Header.Set("X-API-Version", "v2")
+	assert.Nil(t, (&Binder{ProfileHeader: "X-API-Version"}).Bind(req, &a))
+	assert.Equal(t, args{Name: "alice", Age: 3}, a)
+}
+
+type rangeArgs struct {
+	From int `pos:"query:from"`
+	To   int `pos:"query:to"`
+}
+
+func (a *rangeArgs) Validate() error {
+	if a.From > a.To {
+		return fmt.Errorf("from %d is after to %d", a.From, a.To)
+	}
+
+	return nil
+}
+
+func TestBindValidate(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?from=1&to=3", nil)
+	assert.Nil(t, Bind(req, &rangeArgs{}))
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users?from=3&to=1", nil)
+	assert.EqualError(t, Bind(req, &rangeArgs{}), "from 3 is after to 1")
+}
+
+func TestBindTransform(t *testing.T) {
+	type args struct {
+		Email string   `pos:"query:email,trim,lower"`
+		Title string   `pos:"query:title,squash,upper"`
+		Tags  []string `pos:"header:X-Tag,trim"`
+	}
+
+	queries := url.Values{}
+	queries.Set("email", " Bob@Example.COM ")
+	queries.Set("title", "  hello \t  world ")
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?"+queries.Encode(), nil)
+	req.Header.Add("X-Tag", " a ")
+	req.Header.Add("X-Tag", "b ")
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, args{Email: "bob@example.com", Title: "HELLO WORLD", Tags: []string{"a", "b"}}, a)
+	assert.Equal(t, " a ", req.Header.Get("X-Tag"))
+}
+
+func TestBindSanitize(t *testing.T) {
+	type args struct {
+		Name string  `pos:"query:name"`
+		Bio  *string `pos:"query:bio,sanitize=control|html"`
+		Page int     `pos:"query:page"`
+	}
+
+	queries := url.Values{}
+	queries.Set("name", "bobby tables")
+	queries.Set("bio", "<b>hi</b>\x00")
+	queries.Set("page", "12")
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?"+queries.Encode(), nil)
+
+	a := args{}
+	b := &Binder{Sanitize: MaxRunes(5)}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, "bobby", a.Name)
+	assert.Equal(t, "&lt;b&gt;hi&lt;/b&gt;", *a.Bio)
+	assert.Equal(t, 12, a.Page)
+}
+
+func TestBindHeaderWildcard(t *testing.T) {
+	type args struct {
+		All    http.Header         `pos:"header:*"`
+		Custom map[string][]string `pos:"header:x-custom-*"`
+		First  map[string]string   `pos:"header:X-Custom-*"`
+		None   http.Header         `pos:"header:X-None-*"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+	req.Header.Add("X-Custom-A", "1")
+	req.Header.Add("X-Custom-A", "2")
+	req.Header.Set("Accept", "*/*")
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, req.Header, a.All)
+	assert.Equal(t, map[string][]string{"X-Custom-A": {"1", "2"}}, a.Custom)
+	assert.Equal(t, map[string]string{"X-Custom-A": "1"}, a.First)
+	assert.Nil(t, a.None)
+}
+
+func TestBindSplitHeader(t *testing.T) {
+	type args struct {
+		Encodings []string `pos:"header:Accept-Encoding,split"`
+		Tags      []string `pos:"header:X-Tag,split"`
+		Raw       []string `pos:"header:X-Tag"`
+		IDs       []int    `pos:"query:ids,split"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?ids=1,2&ids=3", nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	req.Header.Add("X-Tag", `"a,b", c,,`)
+	req.Header.Add("X-Tag", "d")
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, []string{"gzip", "br"}, a.Encodings)
+	assert.Equal(t, []string{`"a,b"`, "c", "d"}, a.Tags)
+	assert.Equal(t, []string{`"a,b", c,,`, "d"}, a.Raw)
+	assert.Equal(t, []int{1, 2, 3}, a.IDs)
+}
@@ -1,6 +1,7 @@
 package easybind
 
 import (
+	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
@@ -78,6 +79,11 @@ func timeBinder(val string, typ reflect.Type) reflect.Value {
 		}
 	}
 
+	// HTTP-date of headers like If-Modified-Since.
+	if r, err := http.ParseTime(val); err == nil {
+		return reflect.ValueOf(r)
+	}
+
 	if unixInt, err := strconv.ParseInt(val, 10, 64); err == nil {
 		return reflect.ValueOf(time.Unix(unixInt, 0))
 	}
@@ -85,27 +91,6 @@ func timeBinder(val string, typ reflect.Type) reflect.Value {
 	return reflect.Zero(typ)
 }
 
-func pointerBinder(val string, typ reflect.Type) reflect.Value {
-	if len(val) == 0 {
-		return reflect.Zero(typ)
-	}
-
-	v := BindValue(val, typ.Elem())
-	p := reflect.New(v.Type()).Elem()
-	p.Set(v)
-	return p.Addr()
-}
-
-func sliceBinder(vals []string, typ reflect.Type) reflect.Value {
-	slices := reflect.MakeSlice(typ, 0, len(vals))
-	for i := 0; i < len(vals); i++ {
-		val := BindValue(vals[i], typ.Elem())
-		slices = reflect.Append(slices, val.Convert(typ.Elem()))
-	}
-
-	return slices
-}
-
 const (
 	// DefaultDateFormat day
 	DefaultDateFormat = "2006-01-02"
@@ -115,55 +100,27 @@ const (
 	DefaultDatetimeFormatSecond = "2006-01-02 15:04:05"
 )
 
-// BindValue string to specified type
+// BindValue string to specified type, by the binders of the package registry
 func BindValue(val string, typ reflect.Type) reflect.Value {
-	binder, ok := TypeBinders[typ]
-	if !ok {
-		binder, ok = KindBinders[typ.Kind()]
-		if !ok {
-			// WARN.Println("no binder for type:", typ)
-			// TODO slice | struct
-			return reflect.Zero(typ)
-		}
-	}
-
-	return binder(val, typ)
+	return defaultRegistry.bindValue(val, typ)
 }
 
 type binder func(string, reflect.Type) reflect.Value
 
 var (
-	// TimeFormats supported time formats, also support unix time and time.RFC3339.
+	// TimeFormats supported time formats, also support unix time, HTTP-date and time.RFC3339.
+	// They are shared by every registry, set them at init, before binding.
 	TimeFormats []string
 
-	// TypeBinders bind type
-	TypeBinders = make(map[reflect.Type]binder)
+	// TypeBinders bind type, the map of the package registry: add to it, assigning another map
+	// doesn't change what binds.
+	TypeBinders = defaultRegistry.TypeBinders
 
-	// KindBinders bind kind
-	KindBinders = make(map[reflect.Kind]binder)
+	// KindBinders bind kind, the map of the package registry: add to it, assigning another map
+	// doesn't change what binds.
+	KindBinders = defaultRegistry.KindBinders
 )
 
 func init() {
-	KindBinders[reflect.Int] = intBinder
-	KindBinders[reflect.Int8] = intBinder
-	KindBinders[reflect.Int16] = intBinder
-	KindBinders[reflect.Int32] = intBinder
-	KindBinders[reflect.Int64] = intBinder
-
-	KindBinders[reflect.Uint] = uintBinder
-	KindBinders[reflect.Uint8] = uintBinder
-	KindBinders[reflect.Uint16] = uintBinder
-	KindBinders[reflect.Uint32] = uintBinder
-	KindBinders[reflect.Uint64] = uintBinder
-
-	KindBinders[reflect.Float32] = floatBinder
-	KindBinders[reflect.Float64] = floatBinder
-
-	KindBinders[reflect.String] = stringBinder
-	KindBinders[reflect.Bool] = boolBinder
-	KindBinders[reflect.Ptr] = pointerBinder
-
-	TypeBinders[reflect.TypeOf(time.Time{})] = timeBinder
-
 	TimeFormats = append(TimeFormats, DefaultDateFormat, DefaultDatetimeFormat, DefaultDatetimeFormatSecond, time.RFC3339)
 }
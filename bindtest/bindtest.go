@@ -0,0 +1,383 @@
+// Package bindtest builds requests from params structs for handler tests, writing every field
+// where its pos tag binds it from, so a test round-trips through the same tags the handler serves.
+package bindtest
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/momaek/easybind"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// NewRequest returns an incoming server request, as httptest.NewRequest, holding params, a struct or a pointer to struct.
+// Path parameters replace their `{name}` or `:name` placeholder in target, query parameters are added to it.
+// Signed and encrypted cookies are sealed with the keys of easybind.DefaultBinder.
+// Body fields are sent as a JSON object, form fields as a urlencoded form when there is no body field.
+// Zero values are written too, only nil pointers, slices and maps are left out.
+// NewRequest panics as httptest.NewRequest if target or params are invalid.
+func NewRequest(method, target string, params interface{}) *http.Request {
+	return NewRequestWith(easybind.DefaultBinder, method, target, params)
+}
+
+// NewRequestWith same as NewRequest, for params bound by b: the parameters are named by b.FieldName
+// if their tag has no name, the cookies are sealed with the keys of b.
+func NewRequestWith(b *easybind.Binder, method, target string, params interface{}) *http.Request {
+	val := reflect.Indirect(reflect.ValueOf(params))
+	if val.Kind() != reflect.Struct {
+		panic("bindtest: can't build request from nonstruct value")
+	}
+
+	r := &request{
+		binder: b,
+		query:  url.Values{},
+		header: http.Header{},
+		form:   url.Values{},
+		body:   map[string]interface{}{},
+		target: target,
+	}
+	r.addStruct(val, "", "")
+
+	if len(r.query) > 0 {
+		sep := "?"
+		if strings.Contains(r.target, "?") {
+			sep = "&"
+		}
+		r.target += sep + r.query.Encode()
+	}
+
+	var (
+		body        io.Reader
+		contentType string
+	)
+	switch {
+	case len(r.body) > 0:
+		b, err := json.Marshal(r.body)
+		if err != nil {
+			panic("bindtest: " + err.Error())
+		}
+		body, contentType = bytes.NewReader(b), "application/json"
+	case len(r.form) > 0:
+		body, contentType = strings.NewReader(r.form.Encode()), "application/x-www-form-urlencoded"
+	}
+
+	req := httptest.NewRequest(method, r.target, body)
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, c := range r.cookies {
+		req.AddCookie(c)
+	}
+
+	return req
+}
+
+type request struct {
+	binder  *easybind.Binder
+	target  string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	form    url.Values
+	body    map[string]interface{}
+}
+
+// addStruct adds the fields of val, of a nested struct tagged `pos:"source,prefix=..."` if source isn't empty.
+func (r *request) addStruct(val reflect.Value, source, prefix string) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		var (
+			field     = val.Field(i)
+			fieldType = typ.Field(i)
+		)
+
+		if fieldType.Anonymous {
+			if field.Kind() == reflect.Ptr && !field.IsNil() {
+				field = field.Elem()
+			}
+			if field.Kind() == reflect.Struct {
+				r.addStruct(field, source, prefix)
+				continue
+			}
+		}
+
+		if len(fieldType.PkgPath) > 0 {
+			continue
+		}
+
+		tag := r.binder.ParseTag(fieldType)
+		if len(source) > 0 {
+			var ok bool
+			if tag, ok = r.nestedTag(fieldType, source, prefix); !ok {
+				continue
+			}
+		}
+
+		r.addField(field, fieldType, tag)
+	}
+}
+
+// nestedTag returns the tag fieldType, a field of a nested struct bound from source, is bound by:
+// its name prefixed, its json name if it has no pos tag.
+func (r *request) nestedTag(fieldType reflect.StructField, source, prefix string) (easybind.Tag, bool) {
+	if _, tagged := fieldType.Tag.Lookup("pos"); !tagged {
+		name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			return easybind.Tag{}, false
+		case "":
+			name = fieldType.Name
+		}
+
+		return easybind.Tag{Source: source, Name: prefix + name}, true
+	}
+
+	tag := r.binder.ParseTag(fieldType)
+	if tag.Source == "body" {
+		return tag, true
+	}
+
+	tag.Source, tag.Name = source, prefix+tag.Name
+	options := make([]string, len(tag.Options))
+	for i, option := range tag.Options {
+		if strings.HasPrefix(option, "prefix=") {
+			option = "prefix=" + prefix + strings.TrimPrefix(option, "prefix=")
+		}
+		options[i] = option
+	}
+	tag.Options = options
+
+	return tag, true
+}
+
+func (r *request) addField(field reflect.Value, fieldType reflect.StructField, tag easybind.Tag) {
+	if prefix, ok := tag.Get("prefix"); ok {
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct {
+			r.addStruct(field, tag.Source, prefix)
+		}
+		return
+	}
+
+	if _, named := fieldType.Tag.Lookup("json"); !named && fieldType.Tag.Get("pos") == "body" && field.Kind() == reflect.Map {
+		// the map holds the whole body, fields with a json name win
+		iter := field.MapRange()
+		for iter.Next() {
+			if _, ok := r.body[iter.Key().String()]; !ok {
+				r.body[iter.Key().String()] = iter.Value().Interface()
+			}
+		}
+		return
+	}
+
+	if tag.Source == "body" && strings.HasPrefix(tag.Name, "/") {
+		r.addPointer(tag.Name, field.Interface())
+		return
+	}
+
+	if tag.Source == "body" {
+		name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			return
+		case "":
+			name = fieldType.Name
+		}
+
+		r.body[name] = field.Interface()
+		return
+	}
+
+	if strings.HasSuffix(tag.Name, "*") {
+		r.addWildcard(field, tag.Source)
+		return
+	}
+
+	if field.Kind() == reflect.Slice && (tag.Source == "query" || tag.Source == "form") && isStructSlice(field.Type()) {
+		for i := 0; i < field.Len(); i++ {
+			elem := reflect.Indirect(field.Index(i))
+			if elem.IsValid() {
+				r.addStruct(elem, tag.Source, fmt.Sprintf("%s.%d.", tag.Name, i))
+			}
+		}
+		return
+	}
+
+	if field.Kind() == reflect.Map && (tag.Source == "query" || tag.Source == "form") {
+		// parameters grouped by name, e.g. attr.color
+		iter := field.MapRange()
+		for iter.Next() {
+			for _, v := range formatValues(iter.Value()) {
+				if tag.Source == "query" {
+					r.query.Add(tag.Name+"."+iter.Key().String(), v)
+				} else {
+					r.form.Add(tag.Name+"."+iter.Key().String(), v)
+				}
+			}
+		}
+		return
+	}
+
+	values := formatValues(field)
+	if len(values) == 0 {
+		return
+	}
+
+	if tag.Has("split") {
+		values = []string{strings.Join(values, ", ")}
+	}
+
+	for _, v := range values {
+		switch tag.Source {
+		case "path":
+			r.target = strings.NewReplacer("{"+tag.Name+"}", url.PathEscape(v), ":"+tag.Name, url.PathEscape(v)).Replace(r.target)
+		case "query":
+			r.query.Add(tag.Name, v)
+		case "header":
+			r.header.Add(tag.Name, v)
+		case "form":
+			r.form.Add(tag.Name, v)
+		case "cookie":
+			r.cookies = append(r.cookies, &http.Cookie{Name: tag.Name, Value: r.sealCookie(tag, v)})
+		}
+	}
+}
+
+// addWildcard adds every key of field, a map bound by `pos:"header:*"`, `pos:"cookie:*"` or `pos:"query:*"`.
+func (r *request) addWildcard(field reflect.Value, source string) {
+	if field.Kind() != reflect.Map {
+		return
+	}
+
+	iter := field.MapRange()
+	for iter.Next() {
+		for _, v := range formatValues(iter.Value()) {
+			switch source {
+			case "header":
+				r.header.Add(iter.Key().String(), v)
+			case "cookie":
+				r.cookies = append(r.cookies, &http.Cookie{Name: iter.Key().String(), Value: v})
+			case "query":
+				r.query.Add(iter.Key().String(), v)
+			}
+		}
+	}
+}
+
+func (r *request) sealCookie(tag easybind.Tag, value string) string {
+	var err error
+	switch {
+	case tag.Has("encrypted"):
+		value, err = r.binder.EncryptCookie(tag.Name, value)
+	case tag.Has("signed"):
+		value, err = r.binder.SignCookie(tag.Name, value)
+	}
+
+	if err != nil {
+		panic("bindtest: " + err.Error())
+	}
+
+	return value
+}
+
+// formatValues formats val as its binder parses it back, a value per element of slices.
+func formatValues(val reflect.Value) []string {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return formatValues(val.Elem())
+	case reflect.Slice, reflect.Array:
+		if format(val) != nil {
+			break
+		}
+		if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			return []string{string(val.Bytes())}
+		}
+
+		values := make([]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			values = append(values, formatValues(val.Index(i))...)
+		}
+		return values
+	}
+
+	if f := format(val); f != nil {
+		return []string{f()}
+	}
+
+	return []string{fmt.Sprint(val.Interface())}
+}
+
+// format returns the formatter of a value implementing encoding.TextMarshaler or fmt.Stringer, nil if none.
+func format(val reflect.Value) func() string {
+	switch v := val.Interface().(type) {
+	case encoding.TextMarshaler:
+		return func() string {
+			b, err := v.MarshalText()
+			if err != nil {
+				panic("bindtest: " + err.Error())
+			}
+			return string(b)
+		}
+	case fmt.Stringer:
+		return v.String
+	}
+
+	return nil
+}
+
+// addPointer sets the member of the body referenced by the JSON Pointer pointer to value,
+// creating the objects on its way; array indexes are taken as object members.
+func (r *request) addPointer(pointer string, value interface{}) {
+	var (
+		tokens = strings.Split(pointer[1:], "/")
+		obj    = r.body
+	)
+
+	for i, token := range tokens {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if i == len(tokens)-1 {
+			obj[token] = value
+			return
+		}
+
+		next, ok := obj[token].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			obj[token] = next
+		}
+		obj = next
+	}
+}
+
+// isStructSlice reports whether typ is a slice of structs, or of pointers to structs, bound from indexed parameters.
+func isStructSlice(typ reflect.Type) bool {
+	if _, ok := easybind.TypeBinders[typ]; ok {
+		return false
+	}
+
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	_, ok := easybind.TypeBinders[elem]
+	return !ok && elem.Kind() == reflect.Struct
+}
@@ -0,0 +1,111 @@
+package bindtest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/momaek/easybind"
+)
+
+type page struct {
+	Offset int `pos:"query:offset"`
+}
+
+type address struct {
+	City string `pos:"query:city"`
+	Zip  string `json:"zip"`
+}
+
+type listArgs struct {
+	page
+	ID      string            `pos:"path:id"`
+	Tags    []string          `pos:"query:tag"`
+	Since   *time.Time        `pos:"query:since"`
+	IP      net.IP            `pos:"header:X-IP"`
+	Accept  []string          `pos:"header:Accept,split"`
+	Custom  map[string]string `pos:"header:X-Custom-*"`
+	Session string            `pos:"cookie:session"`
+	Name    string            `json:"name"`
+	Title   string            `json:"-" pos:"body:/data/title"`
+	Home    *address          `json:"-" pos:"query,prefix=home_"`
+	Places  []address         `json:"-" pos:"query:places"`
+	Attrs   map[string][]int  `json:"-" pos:"query:attr"`
+	Point   [2]float64        `pos:"query:point"`
+}
+
+func TestNewRequest(t *testing.T) {
+	since := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	params := listArgs{
+		page:    page{Offset: 20},
+		ID:      "a b",
+		Tags:    []string{"x", "y"},
+		Since:   &since,
+		IP:      net.ParseIP("10.0.0.1"),
+		Accept:  []string{"text/html", "application/json"},
+		Custom:  map[string]string{"X-Custom-A": "1"},
+		Session: "s1",
+		Name:    "foo",
+		Title:   "bar",
+		Home:    &address{City: "paris", Zip: "75001"},
+		Places:  []address{{City: "lyon"}, {City: "nice", Zip: "06000"}},
+		Attrs:   map[string][]int{"size": {1, 2}},
+		Point:   [2]float64{48.8, 2.3},
+	}
+
+	req := NewRequest(http.MethodPost, "/items/{id}", &params)
+	assert.Equal(t, "/items/a%20b", req.URL.EscapedPath())
+	assert.Equal(t, "text/html, application/json", req.Header.Get("Accept"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	var bound listArgs
+	assert.Nil(t, easybind.Bind(req, &bound, PathParams(map[string]string{"id": "a b"})))
+	assert.Equal(t, params, bound)
+
+	req = NewRequest(http.MethodGet, "/items?limit=1", struct {
+		Name *string `pos:"query:name"`
+		Note string  `pos:"form:note"`
+	}{Note: "n"})
+	assert.Equal(t, "/items?limit=1", req.URL.String())
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+}
+
+func TestNewRequestWith(t *testing.T) {
+	type filter struct {
+		MinPrice int `pos:"query"`
+	}
+	type args struct {
+		UserID   string  `pos:"path"`
+		PageSize int     `pos:"query"`
+		Filter   *filter `pos:"query,prefix=f_"`
+		Session  string  `pos:"cookie:session,signed"`
+	}
+
+	b := &easybind.Binder{FieldName: easybind.SnakeCase, CookieKeys: [][]byte{[]byte("0123456789abcdef0123456789abcdef")}}
+	params := args{UserID: "42", PageSize: 5, Filter: &filter{MinPrice: 10}, Session: "s1"}
+	req := NewRequestWith(b, http.MethodGet, "/users/{user_id}", &params)
+	assert.Equal(t, "/users/42", req.URL.Path)
+	assert.Equal(t, "5", req.URL.Query().Get("page_size"))
+	assert.Equal(t, "10", req.URL.Query().Get("f_min_price"))
+
+	var bound args
+	assert.Nil(t, b.Bind(req, &bound, PathParams(map[string]string{"user_id": "42"})))
+	assert.Equal(t, params, bound)
+}
+
+func TestPathParams(t *testing.T) {
+	type args struct {
+		ID   int    `pos:"path:id"`
+		Name string `pos:"path:name"`
+	}
+
+	var a args
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	assert.Nil(t, easybind.Bind(req, &a, PathParams(map[string]string{"id": "1"})))
+	assert.Equal(t, args{ID: 1}, a)
+	assert.Equal(t, "1", PathParams(map[string]string{"id": "1"}).ByName("id"))
+}
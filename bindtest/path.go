@@ -0,0 +1,21 @@
+package bindtest
+
+// Params path parameters by name, a path queryier of easybind.Bind as gin.Context or httprouter.Params.
+type Params map[string]string
+
+// PathParams returns params as a path queryier, so `pos:"path:..."` fields bind without a router:
+//
+//	easybind.Bind(req, &args, bindtest.PathParams(map[string]string{"id": "1"}))
+func PathParams(params map[string]string) Params {
+	return Params(params)
+}
+
+// Param returns the value of the path parameter name, as gin.Context.
+func (p Params) Param(name string) string {
+	return p[name]
+}
+
+// ByName returns the value of the path parameter name, as httprouter.Params.
+func (p Params) ByName(name string) string {
+	return p[name]
+}
@@ -0,0 +1,71 @@
+package easybind
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// readBody reads the body of req, which is restored for the next handlers if b.PreserveBody.
+func (b *Binder) readBody(req *http.Request) ([]byte, error) {
+	data, err := b.readAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.PreserveBody {
+		restoreBody(req, data)
+	}
+
+	return data, nil
+}
+
+// parseForm parses the form of req, multipart ones included, its body is restored for the next handlers
+// if b.PreserveBody.
+func (b *Binder) parseForm(req *http.Request) error {
+	parse := req.ParseForm
+	if isMultipart(req) {
+		parse = func() error {
+			err := req.ParseMultipartForm(b.maxMemory())
+			if b.RemoveFilesOnDone {
+				removeFilesOnDone(req)
+			}
+			return err
+		}
+	}
+
+	if !b.PreserveBody || req.Body == nil || req.Body == http.NoBody {
+		return parse()
+	}
+
+	data, err := b.readAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	restoreBody(req, data)
+	err = parse()
+	restoreBody(req, data)
+	return err
+}
+
+// postForm returns the form of the request body, parsed once, empty if its BodyPolicy doesn't read it.
+// err is the error parsing it, e.g. ErrBodyTooLarge, returned by every call.
+func (e *easyReq) postForm() (_ url.Values, err error) {
+	if e.readBody {
+		e.once.Do(func() {
+			e.formErr = e.binder.parseForm(e.req)
+		})
+	}
+
+	return e.req.PostForm, e.formErr
+}
+
+// restoreBody sets the body of req to a reader of data, which may be read again by GetBody.
+func restoreBody(req *http.Request, data []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
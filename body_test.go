@@ -0,0 +1,70 @@
+package easybind
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindPreserveBody(t *testing.T) {
+	b := *DefaultBinder
+	b.PreserveBody = true
+
+	body := `{"age": 3}`
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(body))
+	a := queryUsersArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, 3, a.Age)
+
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, body, string(data))
+	again, _ := req.GetBody()
+	data, _ = io.ReadAll(again)
+	assert.Equal(t, body, string(data))
+
+	type formArgs struct {
+		Name string `pos:"form:name"`
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader("name=bob"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	f := formArgs{}
+	assert.Nil(t, b.Bind(req, &f))
+	assert.Equal(t, "bob", f.Name)
+	data, _ = io.ReadAll(req.Body)
+	assert.Equal(t, "name=bob", string(data))
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(body))
+	assert.Nil(t, Bind(req, &a))
+	data, _ = io.ReadAll(req.Body)
+	assert.Empty(t, data)
+}
+
+func TestBindFormParseError(t *testing.T) {
+	type formArgs struct {
+		Query string `pos:"query:q"`
+		Name  string `pos:"form:name"`
+	}
+
+	newReq := func(contentType, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?q=x", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	b := &Binder{PreserveBody: true, MaxBodySize: 8}
+	err := b.Bind(newReq("application/x-www-form-urlencoded", "name=bobbybobby"), &formArgs{})
+	bindErr := &BindError{}
+	if assert.ErrorAs(t, err, &bindErr) {
+		assert.Equal(t, "Name", bindErr.Field)
+		assert.Equal(t, inTagForm, bindErr.Source)
+	}
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+	err = Bind(newReq("multipart/form-data; boundary=x", "--x\r\nbroken"), &formArgs{})
+	assert.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "Name", bindErr.Field)
+}
@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/momaek/easybind/openapi"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// generators output formats by name.
+var generators = map[string]func(w io.Writer, structs []paramStruct, opts options) error{
+	"markdown": genMarkdown,
+	"openapi":  genOpenAPI,
+	"ts":       genTypeScript,
+	"go":       genGo,
+}
+
+// options of the generators.
+type options struct {
+	// Package name of the generated Go client.
+	Package string
+	// Import path of the package declaring the params structs, used by the Go client.
+	Import string
+}
+
+func genMarkdown(w io.Writer, structs []paramStruct, _ options) error {
+	for _, s := range structs {
+		fmt.Fprintf(w, "## %s\n\n", s.Name)
+		if len(s.Doc) > 0 {
+			fmt.Fprintf(w, "%s\n\n", s.Doc)
+		}
+
+		fmt.Fprintln(w, "| Name | In | Type | Options |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- |")
+		for _, f := range s.Fields {
+			fmt.Fprintf(w, "| `%s` | %s | `%s` | %s |\n", f.Param, f.Source, f.Type, strings.Join(f.Options, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+func genOpenAPI(w io.Writer, structs []paramStruct, _ options) error {
+	ops := make(map[string]*openapi.Operation, len(structs))
+	for _, s := range structs {
+		var (
+			op   = &openapi.Operation{}
+			body = map[string]*openapi.Schema{}
+		)
+
+		for _, f := range s.Fields {
+			schema, required := goSchema(f.Type), f.has("required")
+			if def, ok := f.option("default"); ok {
+				schema.Default = literal(def, schema.Type)
+			}
+
+			switch f.Source {
+			case "path", "query", "header", "cookie":
+				if strings.HasSuffix(f.Param, "*") {
+					continue
+				}
+
+				op.Parameters = append(op.Parameters, openapi.Parameter{
+					Name:     f.Param,
+					In:       f.Source,
+					Required: required || f.Source == "path",
+					Schema:   schema,
+				})
+			case "form", "body":
+				object, ok := body[f.Source]
+				if !ok {
+					object = &openapi.Schema{Type: "object"}
+					body[f.Source] = object
+				}
+
+				if strings.HasPrefix(f.Param, "/") {
+					addPointer(object, f.Param, schema, required)
+					continue
+				}
+
+				addProperty(object, f.Param, schema, required)
+			}
+		}
+
+		for source, mediaType := range map[string]string{"body": "application/json", "form": "application/x-www-form-urlencoded"} {
+			object, ok := body[source]
+			if !ok {
+				continue
+			}
+
+			if op.RequestBody == nil {
+				op.RequestBody = &openapi.RequestBody{Content: map[string]openapi.MediaType{}}
+			}
+			op.RequestBody.Content[mediaType] = openapi.MediaType{Schema: object}
+			op.RequestBody.Required = op.RequestBody.Required || len(object.Required) > 0
+		}
+
+		ops[s.Name] = op
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ops)
+}
+
+// addPointer adds schema to object as the property referenced by pointer, in the objects nesting it.
+func addPointer(object *openapi.Schema, pointer string, schema *openapi.Schema, required bool) {
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if i == len(tokens)-1 {
+			addProperty(object, token, schema, required)
+			return
+		}
+
+		next, ok := object.Properties[token]
+		if !ok {
+			next = &openapi.Schema{Type: "object"}
+			addProperty(object, token, next, required)
+		} else if required && !contains(object.Required, token) {
+			object.Required = append(object.Required, token)
+		}
+		object = next
+	}
+}
+
+func addProperty(object *openapi.Schema, name string, schema *openapi.Schema, required bool) {
+	if object.Properties == nil {
+		object.Properties = map[string]*openapi.Schema{}
+	}
+
+	object.Properties[name] = schema
+	if required {
+		object.Required = append(object.Required, name)
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literal converts a tag value to the json value of a schema of type typ.
+func literal(val, typ string) interface{} {
+	switch typ {
+	case "integer":
+		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return i
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+
+	return val
+}
+
+// goSchema maps a Go type as written in the source to a schema, types of other packages but time.Time are untyped.
+func goSchema(typ string) *openapi.Schema {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		schema := goSchema(typ[1:])
+		schema.Nullable = true
+		return schema
+	case typ == "[]byte":
+		return &openapi.Schema{Type: "string", Format: "byte"}
+	case strings.HasPrefix(typ, "["):
+		return &openapi.Schema{Type: "array", Items: goSchema(typ[strings.Index(typ, "]")+1:])}
+	case strings.HasPrefix(typ, "map["):
+		return &openapi.Schema{Type: "object", AdditionalProperties: goSchema(mapElem(typ))}
+	}
+
+	switch typ {
+	case "bool":
+		return &openapi.Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		return &openapi.Schema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &openapi.Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &openapi.Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &openapi.Schema{Type: "number", Format: "double"}
+	case "string":
+		return &openapi.Schema{Type: "string"}
+	case "time.Time":
+		return &openapi.Schema{Type: "string", Format: "date-time"}
+	}
+
+	return &openapi.Schema{}
+}
+
+// mapElem returns the element type of a map type, keys may be maps themselves.
+func mapElem(typ string) string {
+	depth := 0
+	for i, c := range typ {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return typ[i+1:]
+			}
+		}
+	}
+
+	return ""
+}
+
+// tsType maps a Go type as written in the source to a TypeScript type.
+func tsType(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return tsType(typ[1:]) + " | null"
+	case typ == "[]byte":
+		return "string"
+	case strings.HasPrefix(typ, "["):
+		elem := tsType(typ[strings.Index(typ, "]")+1:])
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case strings.HasPrefix(typ, "map["):
+		return "Record<string, " + tsType(mapElem(typ)) + ">"
+	}
+
+	switch schema := goSchema(typ); schema.Type {
+	case "boolean":
+		return "boolean"
+	case "integer", "number":
+		return "number"
+	case "string":
+		return "string"
+	}
+
+	return "unknown"
+}
+
+// genTypeScript writes an interface and a request builder per struct, the builder returns the url,
+// relative to base, and the init of fetch.
+func genTypeScript(w io.Writer, structs []paramStruct, _ options) error {
+	fmt.Fprintln(w, "// Code generated by easybindgen. DO NOT EDIT.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "// setPointer sets v as the member of body referenced by the JSON Pointer, in the objects nesting it.")
+	fmt.Fprintln(w, "function setPointer(body: Record<string, unknown>, pointer: string, v: unknown): void {")
+	fmt.Fprintln(w, "  const tokens = pointer.slice(1).split(\"/\").map((t) => t.replace(/~1/g, \"/\").replace(/~0/g, \"~\"));")
+	fmt.Fprintln(w, "  let object = body;")
+	fmt.Fprintln(w, "  for (const token of tokens.slice(0, -1)) {")
+	fmt.Fprintln(w, "    object[token] = object[token] ?? {};")
+	fmt.Fprintln(w, "    object = object[token] as Record<string, unknown>;")
+	fmt.Fprintln(w, "  }")
+	fmt.Fprintln(w, "  object[tokens[tokens.length - 1]] = v;")
+	fmt.Fprintln(w, "}")
+	for _, s := range structs {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "export interface %s {\n", s.Name)
+		for _, member := range tsMembers(s.Fields) {
+			fmt.Fprintf(w, "  %s\n", member)
+		}
+		fmt.Fprintln(w, "}")
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "export function build%sRequest(method: string, base: string, path: string, p: %s): [string, RequestInit] {\n", s.Name, s.Name)
+		fmt.Fprintln(w, "  const query = new URLSearchParams();")
+		fmt.Fprintln(w, "  const headers = new Headers();")
+		fmt.Fprintln(w, "  const cookies: string[] = [];")
+		fmt.Fprintln(w, "  const form = new URLSearchParams();")
+		fmt.Fprintln(w, "  const body: Record<string, unknown> = {};")
+		fmt.Fprintln(w, "  let hasForm = false, hasBody = false;")
+		for _, f := range s.Fields {
+			if strings.HasSuffix(f.Param, "*") {
+				continue
+			}
+
+			var set string
+			switch f.Source {
+			case "path":
+				set = fmt.Sprintf("path = path.replace(%q, encodeURIComponent(String(v))).replace(%q, encodeURIComponent(String(v)));", "{"+f.Param+"}", ":"+f.Param)
+			case "query":
+				set = fmt.Sprintf("query.append(%q, String(v));", f.Param)
+			case "header":
+				set = fmt.Sprintf("headers.append(%q, String(v));", f.Param)
+			case "cookie":
+				set = fmt.Sprintf("cookies.push(%q + \"=\" + encodeURIComponent(String(v)));", f.Param)
+			case "form":
+				set = fmt.Sprintf("{ form.append(%q, String(v)); hasForm = true; }", f.Param)
+			case "body":
+				if strings.HasPrefix(f.Param, "/") {
+					fmt.Fprintf(w, "  if (p.%s !== undefined) { setPointer(body, %q, p.%s); hasBody = true; }\n", tsAccess(f.Name), f.Param, tsAccess(f.Name))
+					continue
+				}
+				fmt.Fprintf(w, "  if (p.%s !== undefined) { body[%q] = p.%s; hasBody = true; }\n", tsAccess(f.Name), f.Param, tsAccess(f.Name))
+				continue
+			default:
+				continue
+			}
+
+			fmt.Fprintf(w, "  for (const v of ([] as unknown[]).concat(p.%s ?? [])) %s\n", tsAccess(f.Name), set)
+		}
+		fmt.Fprintln(w, "  if (cookies.length > 0) headers.set(\"Cookie\", cookies.join(\"; \"));")
+		fmt.Fprintln(w, "  const init: RequestInit = { method, headers };")
+		fmt.Fprintln(w, "  if (hasBody) {")
+		fmt.Fprintln(w, "    headers.set(\"Content-Type\", \"application/json\");")
+		fmt.Fprintln(w, "    init.body = JSON.stringify(body);")
+		fmt.Fprintln(w, "  } else if (hasForm) {")
+		fmt.Fprintln(w, "    headers.set(\"Content-Type\", \"application/x-www-form-urlencoded\");")
+		fmt.Fprintln(w, "    init.body = form.toString();")
+		fmt.Fprintln(w, "  }")
+		fmt.Fprintln(w, "  const q = query.toString();")
+		fmt.Fprintln(w, "  return [base + path + (q ? \"?\" + q : \"\"), init];")
+		fmt.Fprintln(w, "}")
+	}
+
+	return nil
+}
+
+// tsMembers returns the members of the interface of fields, those of nested structs grouped in object types.
+func tsMembers(fields []paramField) []string {
+	var (
+		members []string
+		nested  = map[string][]paramField{}
+		at      = map[string]int{}
+	)
+
+	for _, f := range fields {
+		i := strings.Index(f.Name, ".")
+		if i < 0 {
+			optional := "?"
+			if f.Source == "path" || f.has("required") {
+				optional = ""
+			}
+			members = append(members, fmt.Sprintf("%s%s: %s;", f.Name, optional, tsType(f.Type)))
+			continue
+		}
+
+		parent, child := f.Name[:i], f
+		child.Name = f.Name[i+1:]
+		if _, ok := at[parent]; !ok {
+			at[parent] = len(members)
+			members = append(members, "")
+		}
+		nested[parent] = append(nested[parent], child)
+	}
+
+	for parent, children := range nested {
+		members[at[parent]] = fmt.Sprintf("%s?: { %s };", parent, strings.Join(tsMembers(children), " "))
+	}
+
+	return members
+}
+
+// tsAccess returns the expression reading the field name of p, through the nested structs it's a field of.
+func tsAccess(name string) string {
+	return strings.ReplaceAll(name, ".", "?.")
+}
+
+// genGo writes a function per struct building the *http.Request Bind reads it back from.
+// Values are formatted with fmt.Sprint, time.Time as RFC 3339, types whose binder doesn't parse that format
+// need a hand written client.
+func genGo(w io.Writer, structs []paramStruct, opts options) error {
+	if len(opts.Import) == 0 {
+		return fmt.Errorf("go client needs the import path of the params structs")
+	}
+
+	pkg := opts.Import[strings.LastIndex(opts.Import, "/")+1:]
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by easybindgen. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+	fmt.Fprintln(&buf, "import (")
+	for _, imp := range []string{"bytes", "encoding/json", "fmt", "io", "net/http", "net/url", "strings", "time", opts.Import} {
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var (")
+	fmt.Fprintln(&buf, "\t_ = bytes.NewReader")
+	fmt.Fprintln(&buf, "\t_ = json.Marshal")
+	fmt.Fprintln(&buf, "\t_ = strings.NewReader")
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// formatValue formats v as its binder parses it back.")
+	fmt.Fprintln(&buf, "func formatValue(v interface{}) string {")
+	fmt.Fprintln(&buf, "\tif t, ok := v.(time.Time); ok {")
+	fmt.Fprintln(&buf, "\t\treturn t.Format(time.RFC3339Nano)")
+	fmt.Fprintln(&buf, "\t}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "\treturn fmt.Sprint(v)")
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// setPointer sets v as the member of body referenced by the JSON Pointer, in the objects nesting it.")
+	fmt.Fprintln(&buf, "func setPointer(body map[string]interface{}, pointer string, v interface{}) {")
+	fmt.Fprintln(&buf, "\ttokens := strings.Split(pointer[1:], \"/\")")
+	fmt.Fprintln(&buf, "\tfor i, token := range tokens {")
+	fmt.Fprintln(&buf, "\t\ttoken = strings.NewReplacer(\"~1\", \"/\", \"~0\", \"~\").Replace(token)")
+	fmt.Fprintln(&buf, "\t\tif i == len(tokens)-1 {")
+	fmt.Fprintln(&buf, "\t\t\tbody[token] = v")
+	fmt.Fprintln(&buf, "\t\t\treturn")
+	fmt.Fprintln(&buf, "\t\t}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "\t\tnext, ok := body[token].(map[string]interface{})")
+	fmt.Fprintln(&buf, "\t\tif !ok {")
+	fmt.Fprintln(&buf, "\t\t\tnext = map[string]interface{}{}")
+	fmt.Fprintln(&buf, "\t\t\tbody[token] = next")
+	fmt.Fprintln(&buf, "\t\t}")
+	fmt.Fprintln(&buf, "\t\tbody = next")
+	fmt.Fprintln(&buf, "\t}")
+	fmt.Fprintln(&buf, "}")
+
+	for _, s := range structs {
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "// New%sRequest returns a request to rawurl, path parameters written {name} or :name, which binds into p.\n", s.Name)
+		fmt.Fprintf(&buf, "func New%sRequest(method, rawurl string, p *%s.%s) (*http.Request, error) {\n", s.Name, pkg, s.Name)
+		fmt.Fprintln(&buf, "\tvar (")
+		fmt.Fprintln(&buf, "\t\tquery   = url.Values{}")
+		fmt.Fprintln(&buf, "\t\theader  = http.Header{}")
+		fmt.Fprintln(&buf, "\t\tcookies []*http.Cookie")
+		fmt.Fprintln(&buf, "\t\tform    = url.Values{}")
+		fmt.Fprintln(&buf, "\t\tbody    = map[string]interface{}{}")
+		fmt.Fprintln(&buf, "\t)")
+		for _, f := range s.Fields {
+			if strings.HasSuffix(f.Param, "*") {
+				continue
+			}
+
+			var set string
+			switch f.Source {
+			case "path":
+				set = fmt.Sprintf("rawurl = strings.NewReplacer(%q, url.PathEscape(formatValue(v)), %q, url.PathEscape(formatValue(v))).Replace(rawurl)", "{"+f.Param+"}", ":"+f.Param)
+			case "query":
+				set = fmt.Sprintf("query.Add(%q, formatValue(v))", f.Param)
+			case "header":
+				set = fmt.Sprintf("header.Add(%q, formatValue(v))", f.Param)
+			case "cookie":
+				set = fmt.Sprintf("cookies = append(cookies, &http.Cookie{Name: %q, Value: formatValue(v)})", f.Param)
+			case "form":
+				set = fmt.Sprintf("form.Add(%q, formatValue(v))", f.Param)
+			case "body":
+				if strings.HasPrefix(f.Param, "/") {
+					fmt.Fprintf(&buf, "\tsetPointer(body, %q, p.%s)\n", f.Param, f.Name)
+					continue
+				}
+				fmt.Fprintf(&buf, "\tbody[%q] = p.%s\n", f.Param, f.Name)
+				continue
+			default:
+				continue
+			}
+
+			writeGoField(&buf, f, set)
+		}
+		fmt.Fprintln(&buf, "\tif len(query) > 0 {")
+		fmt.Fprintln(&buf, "\t\trawurl += \"?\" + query.Encode()")
+		fmt.Fprintln(&buf, "\t}")
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, "\tvar rd io.Reader")
+		fmt.Fprintln(&buf, "\tswitch {")
+		fmt.Fprintln(&buf, "\tcase len(body) > 0:")
+		fmt.Fprintln(&buf, "\t\tb, err := json.Marshal(body)")
+		fmt.Fprintln(&buf, "\t\tif err != nil {")
+		fmt.Fprintln(&buf, "\t\t\treturn nil, err")
+		fmt.Fprintln(&buf, "\t\t}")
+		fmt.Fprintln(&buf, "\t\trd = bytes.NewReader(b)")
+		fmt.Fprintln(&buf, "\t\theader.Set(\"Content-Type\", \"application/json\")")
+		fmt.Fprintln(&buf, "\tcase len(form) > 0:")
+		fmt.Fprintln(&buf, "\t\trd = strings.NewReader(form.Encode())")
+		fmt.Fprintln(&buf, "\t\theader.Set(\"Content-Type\", \"application/x-www-form-urlencoded\")")
+		fmt.Fprintln(&buf, "\t}")
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, "\treq, err := http.NewRequest(method, rawurl, rd)")
+		fmt.Fprintln(&buf, "\tif err != nil {")
+		fmt.Fprintln(&buf, "\t\treturn nil, err")
+		fmt.Fprintln(&buf, "\t}")
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, "\tfor key, values := range header {")
+		fmt.Fprintln(&buf, "\t\treq.Header[key] = values")
+		fmt.Fprintln(&buf, "\t}")
+		fmt.Fprintln(&buf, "\tfor _, c := range cookies {")
+		fmt.Fprintln(&buf, "\t\treq.AddCookie(c)")
+		fmt.Fprintln(&buf, "\t}")
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, "\treturn req, nil")
+		fmt.Fprintln(&buf, "}")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+// writeGoField writes set, reading the value v, for every value of the field f: once, once if not nil or per element.
+// Fields of nested structs are read if the pointers to them aren't nil.
+func writeGoField(buf *bytes.Buffer, f paramField, set string) {
+	for _, guard := range f.Guards {
+		fmt.Fprintf(buf, "\tif p.%s != nil {\n", guard)
+	}
+	defer func() {
+		for range f.Guards {
+			fmt.Fprintln(buf, "\t}")
+		}
+	}()
+
+	switch {
+	case strings.HasPrefix(f.Type, "*"):
+		fmt.Fprintf(buf, "\tif p.%s != nil {\n\t\tv := *p.%s\n\t\t%s\n\t}\n", f.Name, f.Name, set)
+	case strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" && !f.has("split"):
+		fmt.Fprintf(buf, "\tfor _, v := range p.%s {\n\t\t%s\n\t}\n", f.Name, set)
+	case strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte":
+		fmt.Fprintf(buf, "\tif len(p.%s) > 0 {\n\t\tv := strings.Trim(fmt.Sprint(p.%s), \"[]\")\n\t\tv = strings.Join(strings.Fields(v), \", \")\n\t\t%s\n\t}\n", f.Name, f.Name, set)
+	default:
+		fmt.Fprintf(buf, "\t{\n\t\tv := p.%s\n\t\t%s\n\t}\n", f.Name, set)
+	}
+}
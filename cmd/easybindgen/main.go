@@ -0,0 +1,68 @@
+// Command easybindgen scans packages for params structs tagged with pos and writes their documentation,
+// as Markdown or OpenAPI operations, or client stubs in Go or TypeScript building the requests they bind:
+//
+//	go install github.com/momaek/easybind/cmd/easybindgen@latest
+//	easybindgen -format markdown ./api > API.md
+//	easybindgen -format go -package client -import example.com/app/api ./api > client/api.go
+//
+// Structs are found by parsing the sources, fields of embedded structs declared in the scanned
+// directories are flattened. Without the compiled types, the OpenAPI operations are a subset of those
+// of openapi.Describe: validate rules, indexed rows, grouped parameter maps and the types of other
+// packages but time.Time aren't described.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	var (
+		format = flag.String("format", "markdown", "output format: "+strings.Join(formats(), ", "))
+		opts   options
+	)
+
+	flag.StringVar(&opts.Package, "package", "client", "package name of the go client")
+	flag.StringVar(&opts.Import, "import", "", "import path of the scanned package, required by the go client")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: easybindgen [flags] dir...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*format, flag.Args(), opts); err != nil {
+		fmt.Fprintln(os.Stderr, "easybindgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(format string, dirs []string, opts options) error {
+	gen, ok := generators[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	structs, err := scan(dirs)
+	if err != nil {
+		return err
+	}
+
+	return gen(os.Stdout, structs, opts)
+}
+
+func formats() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/momaek/easybind/cmd/easybindgen/testdata/api"
+	"github.com/momaek/easybind/openapi"
+)
+
+func TestGenerate(t *testing.T) {
+	structs, err := scan([]string{"testdata/api"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(structs))
+
+	s := structs[0]
+	assert.Equal(t, "ListUsers", s.Name)
+	assert.Equal(t, "ListUsers params of GET /groups/{group}/users.", s.Doc)
+	assert.Equal(t, []paramField{
+		{Name: "Offset", Type: "int", Source: "query", Param: "offset", JSONName: "Offset"},
+		{Name: "Limit", Type: "int", Source: "query", Param: "limit", Options: []string{"default=20"}, JSONName: "Limit"},
+		{Name: "Group", Type: "string", Source: "path", Param: "group", JSONName: "Group"},
+		{Name: "Tags", Type: "[]string", Source: "query", Param: "tag", JSONName: "Tags"},
+		{Name: "Since", Type: "*time.Time", Source: "query", Param: "since", JSONName: "Since"},
+		{Name: "Token", Type: "string", Source: "header", Param: "Authorization", Options: []string{"required"}, JSONName: "Token"},
+		{Name: "Session", Type: "string", Source: "cookie", Param: "session", JSONName: "Session"},
+		{Name: "Order", Type: "string", Source: "query", Param: "Order", Options: []string{"required"}, JSONName: "Order"},
+		{Name: "Addr.City", Type: "string", Source: "query", Param: "addr_city", JSONName: "city", Guards: []string{"Addr"}},
+		{Name: "Addr.Zip", Type: "string", Source: "query", Param: "addr_zip", Options: []string{"required"}, JSONName: "Zip", Guards: []string{"Addr"}},
+		{Name: "Name", Type: "string", Source: "body", Param: "name", JSONName: "name"},
+		{Name: "Title", Type: "string", Source: "body", Param: "/profile/title", Options: []string{"required"}, JSONName: "Title"},
+	}, s.Fields)
+
+	var buf bytes.Buffer
+	assert.Nil(t, genMarkdown(&buf, structs, options{}))
+	assert.Contains(t, buf.String(), "| `Authorization` | header | `string` | required |")
+	assert.Contains(t, buf.String(), "| `Order` | query | `string` | required |")
+
+	buf.Reset()
+	assert.Nil(t, genOpenAPI(&buf, structs, options{}))
+	assert.Contains(t, buf.String(), `"in": "path"`)
+	assert.Contains(t, buf.String(), `"name": "addr_zip"`)
+	assert.Contains(t, buf.String(), `"application/json"`)
+
+	// the operations are a subset of those openapi.Describe builds by reflection, equal for these structs
+	var ops map[string]*openapi.Operation
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &ops))
+	for name, params := range map[string]interface{}{"ListUsers": api.ListUsers{}, "Page": api.Page{}} {
+		want, err := openapi.Describe(params)
+		assert.Nil(t, err)
+		wantJSON, _ := json.Marshal(want)
+		gotJSON, _ := json.Marshal(ops[name])
+		assert.JSONEq(t, string(wantJSON), string(gotJSON), name)
+	}
+
+	buf.Reset()
+	assert.Nil(t, genTypeScript(&buf, structs, options{}))
+	assert.Contains(t, buf.String(), "Since?: string | null;")
+	assert.Contains(t, buf.String(), "Tags?: string[];")
+	assert.Contains(t, buf.String(), "Addr?: { City?: string; Zip: string; };")
+	assert.Contains(t, buf.String(), `for (const v of ([] as unknown[]).concat(p.Addr?.City ?? [])) query.append("addr_city", String(v));`)
+	assert.Contains(t, buf.String(), `if (p.Title !== undefined) { setPointer(body, "/profile/title", p.Title); hasBody = true; }`)
+
+	buf.Reset()
+	assert.NotNil(t, genGo(&buf, structs, options{Package: "client"}))
+	assert.Nil(t, genGo(&buf, structs, options{Package: "client", Import: "example.com/app/api"}))
+	_, err = parser.ParseFile(token.NewFileSet(), "client.go", buf.Bytes(), 0)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "func NewListUsersRequest(method, rawurl string, p *api.ListUsers) (*http.Request, error)")
+	assert.Contains(t, buf.String(), "if p.Addr != nil {\n\t\t{\n\t\t\tv := p.Addr.City")
+	assert.Contains(t, buf.String(), `setPointer(body, "/profile/title", p.Title)`)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paramStruct a struct bound by easybind, found in the scanned sources.
+type paramStruct struct {
+	Name   string
+	Doc    string
+	Fields []paramField
+}
+
+// paramField a field of paramStruct, embedded and nested structs flattened.
+type paramField struct {
+	// Name field name, those of nested structs prefixed by theirs, e.g. Addr.City
+	Name string
+	// Type Go type as written in the source, e.g. *string or []time.Time
+	Type     string
+	Source   string
+	Param    string
+	Options  []string
+	JSONName string
+	// Guards the pointers to nested structs Name goes through, checked not nil before it's read, e.g. Addr
+	Guards []string
+}
+
+func (f paramField) has(option string) bool {
+	for _, o := range f.Options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// option returns the value of the option name=value of f.
+func (f paramField) option(name string) (string, bool) {
+	for _, o := range f.Options {
+		if strings.HasPrefix(o, name+"=") {
+			return strings.TrimPrefix(o, name+"="), true
+		}
+	}
+
+	return "", false
+}
+
+// scan parses the Go files of dirs and returns the structs having at least one pos tag, by name.
+func scan(dirs []string) ([]paramStruct, error) {
+	var (
+		fset  = token.NewFileSet()
+		specs = map[string]*ast.TypeSpec{}
+		docs  = map[string]string{}
+	)
+
+	for _, dir := range dirs {
+		pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				for _, decl := range file.Decls {
+					gen, ok := decl.(*ast.GenDecl)
+					if !ok || gen.Tok != token.TYPE {
+						continue
+					}
+
+					for _, spec := range gen.Specs {
+						ts := spec.(*ast.TypeSpec)
+						if _, ok := ts.Type.(*ast.StructType); !ok {
+							continue
+						}
+
+						specs[ts.Name.Name] = ts
+						switch {
+						case ts.Doc != nil:
+							docs[ts.Name.Name] = strings.TrimSpace(ts.Doc.Text())
+						case gen.Doc != nil && len(gen.Specs) == 1:
+							docs[ts.Name.Name] = strings.TrimSpace(gen.Doc.Text())
+						}
+					}
+				}
+			}
+		}
+	}
+
+	structs := make([]paramStruct, 0, len(specs))
+	for name := range specs {
+		fields, tagged := flatten(specs, name, "", "", map[string]bool{})
+		if !tagged || !ast.IsExported(name) {
+			continue
+		}
+
+		structs = append(structs, paramStruct{Name: name, Doc: docs[name], Fields: fields})
+	}
+
+	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+	return structs, nil
+}
+
+// flatten returns the fields of the struct name, fields of embedded structs of the scanned sources included,
+// and whether one of them has a pos tag. Those of nested structs tagged `pos:"source,prefix=..."` are
+// flattened as parameters of source, their names prefixed.
+func flatten(specs map[string]*ast.TypeSpec, name, source, prefix string, seen map[string]bool) (fields []paramField, tagged bool) {
+	if seen[name] {
+		return
+	}
+
+	seen[name] = true
+	defer delete(seen, name)
+
+	for _, field := range specs[name].Type.(*ast.StructType).Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(unquoted)
+		}
+
+		if len(field.Names) == 0 {
+			embedded := strings.TrimPrefix(typeString(field.Type), "*")
+			if _, ok := specs[embedded]; ok {
+				inner, innerTagged := flatten(specs, embedded, source, prefix, seen)
+				fields, tagged = append(fields, inner...), tagged || innerTagged
+			}
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			f := paramField{Name: ident.Name, Type: typeString(field.Type), JSONName: jsonName(tag, ident.Name)}
+			inTag, ok := tag.Lookup("pos")
+			switch {
+			case !ok && len(source) > 0:
+				// field of a nested struct, bound by its json name
+				f.Source, f.Param = source, prefix+f.JSONName
+			case strings.HasPrefix(inTag, "body:/"):
+				// JSON Pointer into the body, e.g. body:/data/attributes/name
+				f.Source, f.Param = "body", strings.TrimSpace(strings.Split(inTag, ",")[0][len("body:"):])
+			case !ok || inTag == "" || strings.HasPrefix(inTag, "body") || strings.HasPrefix(inTag, ","):
+				f.Source, f.Param = "body", f.JSONName
+			default:
+				locs := strings.SplitN(strings.Split(inTag, ",")[0], ":", 2)
+				f.Source, f.Param = strings.TrimSpace(locs[0]), ident.Name
+				if len(locs) == 2 {
+					f.Param = locs[1]
+				}
+				if len(source) > 0 {
+					f.Source, f.Param = source, prefix+f.Param
+				}
+			}
+
+			if ok {
+				tagged = true
+				for _, o := range strings.Split(inTag, ",")[1:] {
+					if o = strings.TrimSpace(o); len(o) > 0 {
+						f.Options = append(f.Options, o)
+					}
+				}
+			}
+
+			if f.Source == "body" && f.Param == "" {
+				continue
+			}
+
+			if nested, ok := f.option("prefix"); ok && f.Source != "body" {
+				typ := strings.TrimPrefix(f.Type, "*")
+				if _, ok := specs[typ]; ok {
+					inner, _ := flatten(specs, typ, f.Source, prefix+nested, seen)
+					for _, innerField := range inner {
+						innerField.Name = ident.Name + "." + innerField.Name
+						for i, guard := range innerField.Guards {
+							innerField.Guards[i] = ident.Name + "." + guard
+						}
+						if strings.HasPrefix(f.Type, "*") {
+							innerField.Guards = append([]string{ident.Name}, innerField.Guards...)
+						}
+						fields = append(fields, innerField)
+					}
+				}
+				continue
+			}
+
+			fields = append(fields, f)
+		}
+	}
+
+	return
+}
+
+func jsonName(tag reflect.StructTag, fieldName string) string {
+	name := strings.Split(tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return fieldName
+	}
+
+	return name
+}
+
+// typeString formats a type expression as written in the source.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + typeString(t.Elt)
+		}
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			return "[" + lit.Value + "]" + typeString(t.Elt)
+		}
+		return "[...]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,34 @@
+package api
+
+import "time"
+
+// Page paging of a list.
+type Page struct {
+	Offset int `pos:"query:offset"`
+	Limit  int `pos:"query:limit,default=20"`
+}
+
+// address of a user.
+type address struct {
+	City string `json:"city"`
+	Zip  string `pos:"query:zip,required"`
+}
+
+// ListUsers params of GET /groups/{group}/users.
+type ListUsers struct {
+	Page
+	Group   string     `pos:"path:group"`
+	Tags    []string   `pos:"query:tag"`
+	Since   *time.Time `pos:"query:since"`
+	Token   string     `pos:"header:Authorization,required"`
+	Session string     `pos:"cookie:session"`
+	Order   string     `pos:"query,required"`
+	Addr    *address   `pos:"query,prefix=addr_"`
+	Name    string     `json:"name"`
+	Title   string     `pos:"body:/profile/title,required"`
+	hidden  string
+}
+
+type notTagged struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,210 @@
+// Command posmigrate adds pos tags equivalent to the gin, echo and gorilla/schema binding tags of struct fields:
+//
+//	go install github.com/momaek/easybind/cmd/posmigrate@latest
+//	posmigrate -l ./...      # list the files to migrate
+//	posmigrate -w ./api      # rewrite them in place
+//
+// The tags are mapped by precedence, the first found wins:
+//
+//	uri:"id", param:"id"        pos:"path:id"
+//	header:"X-Id"               pos:"header:X-Id"
+//	query:"q"                   pos:"query:q"
+//	form:"f", schema:"f"        pos:"query:f", or pos:"form:f" with -form=form
+//
+// binding:"required" of gin and the required option of gorilla/schema become the required option.
+// Fields already having a pos tag are left as is, so does the original tag.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	write = flag.Bool("w", false, "write the result to the source files instead of stdout")
+	list  = flag.Bool("l", false, "list the files whose tags would change")
+	form  = flag.String("form", "query", "pos source of form and schema tags: query or form")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: posmigrate [flags] path...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *form != "query" && *form != "form" {
+		fmt.Fprintln(os.Stderr, "posmigrate: -form must be query or form")
+		os.Exit(2)
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	for _, path := range paths {
+		if err := walk(strings.TrimSuffix(path, "/..."), *form); err != nil {
+			fmt.Fprintln(os.Stderr, "posmigrate:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// walk migrates every Go file under root, vendor, testdata and hidden directories skipped.
+func walk(root, formSource string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(name, ".go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		out, changed, err := migrate(path, src, formSource)
+		if err != nil || !changed {
+			return err
+		}
+
+		switch {
+		case *list:
+			fmt.Println(path)
+		case *write:
+			return os.WriteFile(path, out, info.Mode())
+		default:
+			_, err = os.Stdout.Write(out)
+		}
+
+		return err
+	})
+}
+
+// migrate returns src with pos tags added, formatted by gofmt, and whether any was added.
+func migrate(filename string, src []byte, formSource string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+
+			pos := posTag(reflect.StructTag(tag), formSource)
+			if len(pos) == 0 {
+				continue
+			}
+
+			field.Tag.Value = quoteTag(strings.TrimSpace(tag) + ` pos:"` + pos + `"`)
+			changed = true
+		}
+
+		return true
+	})
+
+	if !changed {
+		return src, false, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, false, err
+	}
+
+	return buf.Bytes(), true, nil
+}
+
+// posTag returns the pos tag equivalent to the binding tags of tag, empty if it has none or already has a pos tag.
+func posTag(tag reflect.StructTag, formSource string) string {
+	if _, ok := tag.Lookup("pos"); ok {
+		return ""
+	}
+
+	var (
+		source, name string
+		required     = strings.Contains(","+tag.Get("binding")+",", ",required,")
+	)
+
+	for _, m := range []struct{ key, source string }{
+		{"uri", "path"},
+		{"param", "path"},
+		{"header", "header"},
+		{"query", "query"},
+		{"form", formSource},
+		{"schema", formSource},
+	} {
+		value, ok := tag.Lookup(m.key)
+		if !ok {
+			continue
+		}
+
+		splits := strings.Split(value, ",")
+		if splits[0] == "-" || len(splits[0]) == 0 {
+			continue
+		}
+
+		source, name = m.source, splits[0]
+		for _, option := range splits[1:] {
+			required = required || option == "required"
+		}
+		break
+	}
+
+	if len(source) == 0 {
+		return ""
+	}
+
+	pos := source + ":" + name
+	if required {
+		pos += ",required"
+	}
+
+	return pos
+}
+
+// quoteTag quotes tag as a raw string unless it contains a backquote.
+func quoteTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+
+	return "`" + tag + "`"
+}
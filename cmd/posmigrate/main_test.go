@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	src := "package api\n\n" +
+		"type args struct {\n" +
+		"\tID    string `uri:\"id\" binding:\"required\"`\n" +
+		"\tPage  int    `form:\"page\"`\n" +
+		"\tQ     string `query:\"q\" json:\"q\"`\n" +
+		"\tToken string `header:\"Authorization\"`\n" +
+		"\tName  string `schema:\"name,required\"`\n" +
+		"\tSkip  string `form:\"-\"`\n" +
+		"\tDone  string `form:\"done\" pos:\"query:done\"`\n" +
+		"}\n"
+
+	out, changed, err := migrate("api.go", []byte(src), "form")
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "package api\n\n"+
+		"type args struct {\n"+
+		"\tID    string `uri:\"id\" binding:\"required\" pos:\"path:id,required\"`\n"+
+		"\tPage  int    `form:\"page\" pos:\"form:page\"`\n"+
+		"\tQ     string `query:\"q\" json:\"q\" pos:\"query:q\"`\n"+
+		"\tToken string `header:\"Authorization\" pos:\"header:Authorization\"`\n"+
+		"\tName  string `schema:\"name,required\" pos:\"form:name,required\"`\n"+
+		"\tSkip  string `form:\"-\"`\n"+
+		"\tDone  string `form:\"done\" pos:\"query:done\"`\n"+
+		"}\n", string(out))
+
+	_, changed, err = migrate("api.go", out, "form")
+	assert.Nil(t, err)
+	assert.False(t, changed)
+}
@@ -0,0 +1,79 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ETag entity tag of If-Match and If-None-Match headers.
+type ETag struct {
+	// Value opaque tag without quotes, `*` matches any entity
+	Value string
+	// Weak tag prefixed by W/
+	Weak bool
+}
+
+func (t ETag) String() string {
+	if t.Value == wildcard {
+		return wildcard
+	}
+
+	if t.Weak {
+		return `W/"` + t.Value + `"`
+	}
+
+	return `"` + t.Value + `"`
+}
+
+// ETags list of If-Match or If-None-Match tags, bind with `pos:"header:If-None-Match"`.
+type ETags []ETag
+
+// Match reports whether etag, e.g. `"xyz"` or `W/"xyz"` matches one of t by weak comparison,
+// as If-None-Match does. Use StrongMatch for If-Match.
+func (t ETags) Match(etag string) bool {
+	other := parseETag(etag)
+	for _, tag := range t {
+		if tag.Value == wildcard || tag.Value == other.Value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StrongMatch reports whether etag matches one of t by strong comparison, weak tags never match.
+func (t ETags) StrongMatch(etag string) bool {
+	other := parseETag(etag)
+	for _, tag := range t {
+		if tag.Value == wildcard {
+			return true
+		}
+
+		if !tag.Weak && !other.Weak && tag.Value == other.Value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(s string) (tag ETag) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "W/") {
+		tag.Weak = true
+		s = s[2:]
+	}
+
+	tag.Value = strings.Trim(s, `"`)
+	return
+}
+
+func etagsBinder(val string, typ reflect.Type) reflect.Value {
+	list := splitList([]string{val})
+	tags := make(ETags, 0, len(list))
+	for _, s := range list {
+		tags = append(tags, parseETag(s))
+	}
+
+	return reflect.ValueOf(tags)
+}
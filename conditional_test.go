@@ -0,0 +1,34 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindConditionalHeaders(t *testing.T) {
+	type args struct {
+		IfModifiedSince time.Time `pos:"header:If-Modified-Since"`
+		IfNoneMatch     ETags     `pos:"header:If-None-Match"`
+		IfMatch         ETags     `pos:"header:If-Match"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users/1", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 21 Oct 2015 07:28:00 GMT")
+	req.Header.Add("If-None-Match", `W/"v1", "v,2"`)
+	req.Header.Add("If-None-Match", `"v3"`)
+	req.Header.Set("If-Match", "*")
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.True(t, time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC).Equal(a.IfModifiedSince))
+	assert.Equal(t, ETags{{Value: "v1", Weak: true}, {Value: "v,2"}, {Value: "v3"}}, a.IfNoneMatch)
+	assert.True(t, a.IfNoneMatch.Match(`"v1"`))
+	assert.False(t, a.IfNoneMatch.StrongMatch(`"v1"`))
+	assert.True(t, a.IfNoneMatch.StrongMatch(`"v3"`))
+	assert.False(t, a.IfNoneMatch.Match(`"v4"`))
+	assert.True(t, a.IfMatch.StrongMatch(`"any"`))
+	assert.Equal(t, `W/"v1"`, a.IfNoneMatch[0].String())
+}
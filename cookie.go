@@ -0,0 +1,159 @@
+package easybind
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	optionSigned    = "signed"
+	optionEncrypted = "encrypted"
+)
+
+var (
+	// ErrCookieTampered a signed or encrypted cookie doesn't verify with any of Binder.CookieKeys
+	ErrCookieTampered = errors.New("cookie is tampered")
+	// ErrNoCookieKey Binder.CookieKeys is empty
+	ErrNoCookieKey = errors.New("no cookie key")
+
+	cookieEncoding = base64.RawURLEncoding
+)
+
+// cookieValues returns the values of the cookies named name, verified if signed or decrypted if encrypted.
+func (e *easyReq) cookieValues(name string, signed, encrypted bool) (values []string, err error) {
+	for _, c := range e.req.Cookies() {
+		if c.Name != name {
+			continue
+		}
+
+		v := c.Value
+		switch {
+		case encrypted:
+			v, err = e.binder.DecryptCookie(name, v)
+		case signed:
+			v, err = e.binder.VerifyCookie(name, v)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return
+}
+
+// SignCookie returns the value of cookie name signed by HMAC-SHA256 with the first of b.CookieKeys,
+// bind it with `pos:"cookie:name,signed"`.
+func (b *Binder) SignCookie(name, value string) (string, error) {
+	if len(b.CookieKeys) == 0 {
+		return "", ErrNoCookieKey
+	}
+
+	return cookieEncoding.EncodeToString([]byte(value)) + "." + cookieEncoding.EncodeToString(cookieMAC(b.CookieKeys[0], name, value)), nil
+}
+
+// VerifyCookie returns the value of cookie name signed by SignCookie with one of b.CookieKeys.
+func (b *Binder) VerifyCookie(name, signed string) (string, error) {
+	if len(b.CookieKeys) == 0 {
+		return "", ErrNoCookieKey
+	}
+
+	i := strings.LastIndex(signed, ".")
+	if i < 0 {
+		return "", ErrCookieTampered
+	}
+
+	value, err := cookieEncoding.DecodeString(signed[:i])
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	mac, err := cookieEncoding.DecodeString(signed[i+1:])
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	for _, key := range b.CookieKeys {
+		if hmac.Equal(mac, cookieMAC(key, name, string(value))) {
+			return string(value), nil
+		}
+	}
+
+	return "", ErrCookieTampered
+}
+
+// EncryptCookie returns the value of cookie name encrypted by AES-GCM with the first of b.CookieKeys,
+// bind it with `pos:"cookie:name,encrypted"`.
+func (b *Binder) EncryptCookie(name, value string) (string, error) {
+	if len(b.CookieKeys) == 0 {
+		return "", ErrNoCookieKey
+	}
+
+	aead, err := cookieAEAD(b.CookieKeys[0])
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return cookieEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(value), []byte(name))), nil
+}
+
+// DecryptCookie returns the value of cookie name encrypted by EncryptCookie with one of b.CookieKeys.
+func (b *Binder) DecryptCookie(name, encrypted string) (string, error) {
+	if len(b.CookieKeys) == 0 {
+		return "", ErrNoCookieKey
+	}
+
+	data, err := cookieEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	for _, key := range b.CookieKeys {
+		aead, err := cookieAEAD(key)
+		if err != nil {
+			return "", err
+		}
+
+		if len(data) < aead.NonceSize() {
+			return "", ErrCookieTampered
+		}
+
+		if value, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(name)); err == nil {
+			return string(value), nil
+		}
+	}
+
+	return "", ErrCookieTampered
+}
+
+// cookieMAC signs the name too, so a cookie can't be replayed under another name.
+func cookieMAC(key []byte, name, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name + "=" + value))
+	return mac.Sum(nil)
+}
+
+// cookieAEAD derives an AES-256 key from key, so keys of any length work.
+func cookieAEAD(key []byte) (cipher.AEAD, error) {
+	sum := sha256.Sum256(key)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,75 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindCookie(t *testing.T) {
+	type args struct {
+		Theme   string `pos:"cookie:theme"`
+		UserID  int    `pos:"cookie:uid,signed"`
+		Session string `pos:"cookie:session,encrypted"`
+	}
+
+	var (
+		old = &Binder{CookieKeys: [][]byte{[]byte("old key")}}
+		b   = &Binder{CookieKeys: [][]byte{[]byte("new key"), []byte("old key")}}
+	)
+
+	uid, err := old.SignCookie("uid", "42")
+	assert.Nil(t, err)
+	session, err := b.EncryptCookie("session", "s3cr3t")
+	assert.Nil(t, err)
+
+	newReq := func(cookies ...*http.Cookie) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		return req
+	}
+
+	a := args{}
+	assert.Nil(t, b.Bind(newReq(&http.Cookie{Name: "theme", Value: "dark"}, &http.Cookie{Name: "uid", Value: uid}, &http.Cookie{Name: "session", Value: session}), &a))
+	assert.Equal(t, args{Theme: "dark", UserID: 42, Session: "s3cr3t"}, a)
+
+	forged, _ := (&Binder{CookieKeys: [][]byte{[]byte("forged")}}).SignCookie("uid", "1")
+	err = b.Bind(newReq(&http.Cookie{Name: "uid", Value: forged}), &args{})
+	assert.True(t, errors.Is(err, ErrCookieTampered))
+
+	renamed, _ := b.SignCookie("other", "1")
+	err = b.Bind(newReq(&http.Cookie{Name: "uid", Value: renamed}), &args{})
+	assert.True(t, errors.Is(err, ErrCookieTampered))
+
+	err = b.Bind(newReq(&http.Cookie{Name: "session", Value: session[:len(session)-2]}), &args{})
+	assert.True(t, errors.Is(err, ErrCookieTampered))
+
+	// a missing configuration isn't reported as an attack
+	err = Bind(newReq(&http.Cookie{Name: "uid", Value: uid}), &args{})
+	assert.True(t, errors.Is(err, ErrNoCookieKey))
+	err = Bind(newReq(&http.Cookie{Name: "session", Value: session}), &args{})
+	assert.True(t, errors.Is(err, ErrNoCookieKey))
+}
+
+func TestBindCookieWildcard(t *testing.T) {
+	type args struct {
+		All    map[string]string   `pos:"cookie:*"`
+		Prefs  map[string][]string `pos:"cookie:pref_*"`
+		Others map[string]string   `pos:"cookie:none_*"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+	req.AddCookie(&http.Cookie{Name: "pref_theme", Value: "dark"})
+	req.AddCookie(&http.Cookie{Name: "pref_lang", Value: "en"})
+	req.AddCookie(&http.Cookie{Name: "sid", Value: "1"})
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, map[string]string{"pref_theme": "dark", "pref_lang": "en", "sid": "1"}, a.All)
+	assert.Equal(t, map[string][]string{"pref_theme": {"dark"}, "pref_lang": {"en"}}, a.Prefs)
+	assert.Nil(t, a.Others)
+}
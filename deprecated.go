@@ -0,0 +1,79 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// optionDeprecated marks a parameter deprecated, `pos:"query:limit,deprecated"`,
+// or names its deprecated aliases, `pos:"query:page_size,deprecated=limit|size"`, `|` separates several names.
+const optionDeprecated = "deprecated"
+
+// Deprecation a deprecated parameter sent by the client.
+type Deprecation struct {
+	// Field struct field name
+	Field string
+	// Source where the parameter was found
+	Source string
+	// Name deprecated parameter name
+	Name string
+	// Use parameter name to use instead, empty if the parameter is deprecated without replacement
+	Use string
+}
+
+func (d Deprecation) String() string {
+	s := d.Source + " parameter " + d.Name + " is deprecated"
+	if len(d.Use) > 0 {
+		s += ", use " + d.Use
+	}
+
+	return s
+}
+
+// AddWarning adds d as a Warning header, code 299, to h, e.g. the header of the response:
+//
+//	b := *easybind.DefaultBinder
+//	b.OnDeprecated = func(_ *http.Request, d easybind.Deprecation) {
+//		easybind.AddWarning(w.Header(), d)
+//	}
+//	err := b.Bind(req, &args)
+func AddWarning(h http.Header, d Deprecation) {
+	h.Add("Warning", "299 - "+strconv.Quote(d.String()))
+}
+
+// deprecatedValues returns the first deprecated alias of the field found in loc and its values.
+func (e *easyReq) deprecatedValues(fieldType reflect.StructField, loc string) (name string, values []string) {
+	aliases, ok := getInTagOption(fieldType, optionDeprecated)
+	if !ok {
+		return
+	}
+
+	for _, alias := range strings.Split(aliases, optionFieldSep) {
+		if values, ok, _ := e.sourceValues(fieldType, loc, alias); ok && len(values) > 0 {
+			return alias, values
+		}
+	}
+
+	return "", nil
+}
+
+// checkDeprecated reports name, found in loc, to Binder.OnDeprecated if it's deprecated.
+func (e *easyReq) checkDeprecated(fieldType reflect.StructField, loc, name string) {
+	if e.binder.OnDeprecated == nil {
+		return
+	}
+
+	d := Deprecation{Field: fieldType.Name, Source: loc, Name: name}
+	if _, canonical := getInTagLocAndName(fieldType); name != canonical {
+		d.Use = canonical
+	} else if !hasInTagOption(fieldType, optionDeprecated) {
+		return
+	}
+
+	// fields are bound concurrently, calls are serialized so the hook needs no locking
+	e.mu.Lock()
+	e.binder.OnDeprecated(e.req, d)
+	e.mu.Unlock()
+}
@@ -0,0 +1,38 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindDeprecated(t *testing.T) {
+	type args struct {
+		PageSize int    `pos:"query:page_size,deprecated=limit|size"`
+		Sort     string `pos:"query:sort,deprecated"`
+		Filter   string `pos:"query:filter,deprecated=q"`
+	}
+
+	var (
+		deprecations []Deprecation
+		b            = *DefaultBinder
+	)
+	b.OnDeprecated = func(_ *http.Request, d Deprecation) {
+		deprecations = append(deprecations, d)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?size=10&sort=name&filter=a&q=b", nil)
+	a := args{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, args{PageSize: 10, Sort: "name", Filter: "a"}, a)
+	assert.ElementsMatch(t, []Deprecation{
+		{Field: "PageSize", Source: "query", Name: "size", Use: "page_size"},
+		{Field: "Sort", Source: "query", Name: "sort"},
+	}, deprecations)
+
+	h := http.Header{}
+	AddWarning(h, deprecations[0])
+	AddWarning(h, Deprecation{Source: "query", Name: "size", Use: "page_size"})
+	assert.Equal(t, `299 - "query parameter size is deprecated, use page_size"`, h.Values("Warning")[1])
+}
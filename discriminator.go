@@ -0,0 +1,186 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// Discriminators registry of the concrete types of interface values decoded from json bodies,
+// selected by a discriminator property, e.g. {"type": "card", ...} decoded into a *CardPayment.
+// Set it as Binder.Discriminators, then bind params with interface fields, or a pointer to the interface itself:
+//
+//	var payment Payment
+//	err := binder.Bind(req, &payment)
+type Discriminators struct {
+	mu    sync.RWMutex
+	types map[reflect.Type]*discriminator
+	api   jsoniter.API
+}
+
+type discriminator struct {
+	iface    reflect.Type
+	property string
+	types    map[string]reflect.Type
+}
+
+// Register registers the concrete types of iface, a pointer to an interface such as (*Payment)(nil),
+// by the values of property, e.g. map[string]interface{}{"card": &CardPayment{}, "bank": BankPayment{}}.
+// Register panics if a type doesn't implement the interface.
+func (d *Discriminators) Register(iface interface{}, property string, types map[string]interface{}) {
+	typ := reflect.TypeOf(iface)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+		panic("easybind: discriminated type must be a pointer to an interface")
+	}
+
+	disc := &discriminator{iface: typ.Elem(), property: property, types: make(map[string]reflect.Type, len(types))}
+	for value, concrete := range types {
+		ct := reflect.TypeOf(concrete)
+		if ct == nil || !ct.Implements(disc.iface) {
+			panic(fmt.Sprintf("easybind: %v doesn't implement %v", ct, disc.iface))
+		}
+		disc.types[value] = ct
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// decoders read the types concurrently, registering copies them
+	registered := make(map[reflect.Type]*discriminator, len(d.types)+1)
+	for t, other := range d.types {
+		registered[t] = other
+	}
+	registered[disc.iface] = disc
+	d.types = registered
+
+	// decoders are cached by the config, so is a new one
+	d.api = jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true}.Froze()
+	d.api.RegisterExtension(&discriminatorExtension{api: d.api, types: d.types})
+}
+
+// lookup returns the discriminator of the interface type typ.
+func (d *Discriminators) lookup(typ reflect.Type) (*discriminator, bool) {
+	if d == nil {
+		return nil, false
+	}
+
+	d.mu.RLock()
+	disc, ok := d.types[typ]
+	d.mu.RUnlock()
+	return disc, ok
+}
+
+// jsonAPI returns the json config decoding the registered interfaces, the default one if none.
+func (d *Discriminators) jsonAPI() jsoniter.API {
+	if d == nil {
+		return json
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.api == nil {
+		return json
+	}
+
+	return d.api
+}
+
+type discriminatorExtension struct {
+	jsoniter.DummyExtension
+	api   jsoniter.API
+	types map[reflect.Type]*discriminator
+}
+
+func (x *discriminatorExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	if disc, ok := x.types[typ.Type1()]; ok {
+		return &discriminatorDecoder{api: x.api, disc: disc}
+	}
+
+	return nil
+}
+
+type discriminatorDecoder struct {
+	api  jsoniter.API
+	disc *discriminator
+}
+
+func (dec *discriminatorDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	field := reflect.NewAt(dec.disc.iface, ptr).Elem()
+	if iter.WhatIsNext() == jsoniter.NilValue {
+		iter.Skip()
+		field.Set(reflect.Zero(dec.disc.iface))
+		return
+	}
+
+	val, err := dec.disc.decode(dec.api, iter.SkipAndReturnBytes())
+	if err != nil {
+		iter.ReportError("decode "+dec.disc.iface.String(), err.Error())
+		return
+	}
+
+	field.Set(val)
+}
+
+// decode decodes data into the concrete type selected by its discriminator property.
+func (disc *discriminator) decode(api jsoniter.API, data []byte) (reflect.Value, error) {
+	kind := api.Get(data, disc.property)
+	if kind.ValueType() != jsoniter.StringValue {
+		return reflect.Value{}, fmt.Errorf("missing discriminator %q", disc.property)
+	}
+
+	typ, ok := disc.types[kind.ToString()]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("unknown %s %q", disc.property, kind.ToString())
+	}
+
+	elem := typ
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	val := reflect.New(elem)
+	if err := api.Unmarshal(data, val.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if typ.Kind() != reflect.Ptr {
+		return val.Elem(), nil
+	}
+
+	return val, nil
+}
+
+// bindDiscriminated decodes the json body of req into val, a registered interface.
+func (b *Binder) bindDiscriminated(req *http.Request, val reflect.Value) error {
+	disc, ok := b.Discriminators.lookup(val.Type())
+	if !ok {
+		return errors.New("can't bind to nonstruct value")
+	}
+
+	if req.ContentLength == 0 || req.Body == nil {
+		return ErrRequired
+	}
+
+	data, err := b.readBody(req)
+	if err != nil {
+		return err
+	}
+
+	if err = b.checkJSONLimits(data); err != nil {
+		return err
+	}
+
+	concrete, err := disc.decode(b.Discriminators.jsonAPI(), data)
+	if err != nil {
+		return err
+	}
+
+	val.Set(concrete)
+	return validate(req.Context(), concrete.Interface())
+}
@@ -0,0 +1,67 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type payment interface {
+	amount() int
+}
+
+type cardPayment struct {
+	Amount int    `json:"amount"`
+	Card   string `json:"card"`
+}
+
+func (p *cardPayment) amount() int { return p.Amount }
+
+type bankPayment struct {
+	Amount int    `json:"amount"`
+	IBAN   string `json:"iban"`
+}
+
+func (p bankPayment) amount() int { return p.Amount }
+
+func TestBindDiscriminated(t *testing.T) {
+	b := *DefaultBinder
+	b.Discriminators = &Discriminators{}
+	b.Discriminators.Register((*payment)(nil), "type", map[string]interface{}{
+		"card": &cardPayment{},
+		"bank": bankPayment{},
+	})
+	assert.Panics(t, func() {
+		b.Discriminators.Register((*payment)(nil), "type", map[string]interface{}{"card": cardPayment{}})
+	})
+
+	type args struct {
+		ID       string    `pos:"query:id"`
+		Payment  payment   `json:"payment"`
+		Payments []payment `json:"payments"`
+	}
+
+	body := `{"payment": {"type": "card", "amount": 1, "card": "4242"}, "payments": [{"type": "bank", "amount": 2, "iban": "DE1"}, null]}`
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/payments?id=1", strings.NewReader(body))
+	a := args{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, args{
+		ID:       "1",
+		Payment:  &cardPayment{Amount: 1, Card: "4242"},
+		Payments: []payment{bankPayment{Amount: 2, IBAN: "DE1"}, nil},
+	}, a)
+
+	var p payment
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/webhook", strings.NewReader(`{"type": "bank", "amount": 3}`))
+	assert.Nil(t, b.Bind(req, &p))
+	assert.Equal(t, bankPayment{Amount: 3}, p)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/webhook", strings.NewReader(`{"type": "cash"}`))
+	assert.EqualError(t, b.Bind(req, &p), `unknown type "cash"`)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/payments", strings.NewReader(`{"payment": {"amount": 1}}`))
+	assert.Contains(t, b.Bind(req, &args{}).Error(), `missing discriminator "type"`)
+	assert.NotNil(t, Bind(req, &p))
+}
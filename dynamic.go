@@ -0,0 +1,61 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// isBodyMap reports whether fieldType is a map tagged `pos:"body"` without a json name,
+// which receives the whole json body, e.g. the properties a gateway doesn't know in advance.
+func isBodyMap(fieldType reflect.StructField) bool {
+	_, named := fieldType.Tag.Lookup("json")
+	return fieldType.Type.Kind() == reflect.Map && fieldType.Tag.Get(tagNameIn) == inTagBody && !named
+}
+
+// bodyMap a map field receiving the whole json body.
+type bodyMap struct {
+	name  string
+	field reflect.Value
+}
+
+func (e *easyReq) addBodyMap(field reflect.Value, fieldType reflect.StructField) {
+	e.mu.Lock()
+	e.hasJSONBody = true
+	e.bodyMaps = append(e.bodyMaps, bodyMap{name: fieldType.Name, field: field})
+	e.mu.Unlock()
+}
+
+// decodeBodyMaps decodes data, the json body, into every map field tagged `pos:"body"`.
+func (e *easyReq) decodeBodyMaps(data []byte) error {
+	api := e.binder.Discriminators.jsonAPI()
+	for _, bm := range e.bodyMaps {
+		m := reflect.New(bm.field.Type())
+		if err := api.Unmarshal(data, m.Interface()); err != nil {
+			return err
+		}
+
+		bm.field.Set(m.Elem())
+		e.setField(bm.name, !m.Elem().IsNil())
+	}
+
+	return nil
+}
+
+// bindDynamic decodes the json body of req into params, a pointer to a map or to an empty interface,
+// for components which don't know the schema in advance.
+func (b *Binder) bindDynamic(req *http.Request, params interface{}) error {
+	if req.ContentLength == 0 || req.Body == nil {
+		return nil
+	}
+
+	data, err := b.readBody(req)
+	if err != nil {
+		return err
+	}
+
+	if err = b.checkJSONLimits(data); err != nil {
+		return err
+	}
+
+	return b.Discriminators.jsonAPI().Unmarshal(data, params)
+}
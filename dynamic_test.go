@@ -0,0 +1,39 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindDynamic(t *testing.T) {
+	body := `{"name": "bob", "tags": ["a"], "age": 3}`
+
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/proxy", strings.NewReader(body))
+	var m map[string]interface{}
+	assert.Nil(t, Bind(req, &m))
+	assert.Equal(t, map[string]interface{}{"name": "bob", "tags": []interface{}{"a"}, "age": float64(3)}, m)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/proxy", strings.NewReader(body))
+	var v interface{}
+	assert.Nil(t, Bind(req, &v))
+	assert.Equal(t, m, v)
+
+	type args struct {
+		ID    string                 `pos:"query:id"`
+		Name  string                 `json:"name"`
+		Extra map[string]interface{} `pos:"body"`
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/proxy?id=1", strings.NewReader(body))
+	a := args{}
+	fields, err := BindFieldSet(req, &a)
+	assert.Nil(t, err)
+	assert.Equal(t, args{ID: "1", Name: "bob", Extra: m}, a)
+	assert.True(t, fields.Has("Extra"))
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/proxy", strings.NewReader(`[1]`))
+	assert.NotNil(t, Bind(req, &m))
+}
@@ -0,0 +1,43 @@
+package easybind
+
+import "reflect"
+
+// embeddedStruct returns the struct type of fieldType if it embeds a struct or a pointer to struct.
+func embeddedStruct(fieldType reflect.StructField) (reflect.Type, bool) {
+	if !fieldType.Anonymous {
+		return nil, false
+	}
+
+	typ := fieldType.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ, typ.Kind() == reflect.Struct
+}
+
+// allocPointer allocates field, a nil pointer to an embedded or nested struct, so its fields can be bound;
+// pruneEmbedded resets it once bound if none was.
+func (e *easyReq) allocPointer(field reflect.Value) {
+	field.Set(reflect.New(field.Type().Elem()))
+
+	e.mu.Lock()
+	e.embedded = append(e.embedded, field)
+	e.mu.Unlock()
+}
+
+// pruneEmbedded resets the pointers allocated by allocPointer, and the interface fields set by their factory,
+// to nil if nothing was bound into them, the innermost first.
+func (e *easyReq) pruneEmbedded() {
+	for i := len(e.embedded) - 1; i >= 0; i-- {
+		field := e.embedded[i]
+		val := field.Elem()
+		if field.Kind() == reflect.Interface {
+			val = val.Elem()
+		}
+
+		if val.IsZero() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
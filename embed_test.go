@@ -0,0 +1,122 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type EmbeddedPage struct {
+	Offset int `pos:"query:offset"`
+	Limit  int `pos:"query:limit"`
+}
+
+type EmbeddedAudit struct {
+	Note string `json:"note"`
+}
+
+type EmbeddedID struct {
+	ID string `pos:"query:id,required"`
+}
+
+type listEmbeddedArgs struct {
+	*EmbeddedPage
+	*EmbeddedAudit
+	Name string `pos:"query:name"`
+}
+
+func TestBindEmbeddedPointer(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?offset=10&name=bob", strings.NewReader(`{"note": "n"}`))
+	a := listEmbeddedArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, &EmbeddedPage{Offset: 10}, a.EmbeddedPage)
+	assert.Equal(t, &EmbeddedAudit{Note: "n"}, a.EmbeddedAudit)
+	assert.Equal(t, "bob", a.Name)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users?name=bob", nil)
+	a = listEmbeddedArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Nil(t, a.EmbeddedPage)
+	assert.Nil(t, a.EmbeddedAudit)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users?limit=5", nil)
+	a = listEmbeddedArgs{EmbeddedPage: &EmbeddedPage{Offset: 1}}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, &EmbeddedPage{Offset: 1, Limit: 5}, a.EmbeddedPage)
+
+	type requiredArgs struct {
+		*EmbeddedID
+	}
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+	assert.ErrorIs(t, Bind(req, &requiredArgs{}), ErrRequired)
+}
+
+type UserID string
+
+type Locale string
+
+type Token int
+
+type pathParams map[string]string
+
+func (p pathParams) ByName(name string) string { return p[name] }
+
+type profileArgs struct {
+	UserID  `pos:"path:id"`
+	*Locale `pos:"query:locale"`
+	Token   `pos:"header:X-Token"`
+}
+
+func TestBindEmbeddedNonStruct(t *testing.T) {
+	assert.Nil(t, Register(&profileArgs{}))
+
+	TypeBinders[reflect.TypeOf(Locale(""))] = func(val string, typ reflect.Type) reflect.Value {
+		return reflect.ValueOf(Locale(strings.ToLower(val)))
+	}
+	defer delete(TypeBinders, reflect.TypeOf(Locale("")))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users/1?locale=EN", nil)
+	req.Header.Set("X-Token", "42")
+	a := profileArgs{}
+	assert.Nil(t, Bind(req, &a, pathParams{"id": "u1"}))
+	assert.Equal(t, UserID("u1"), a.UserID)
+	assert.Equal(t, Locale("en"), *a.Locale)
+	assert.Equal(t, Token(42), a.Token)
+}
+
+type filterParams struct {
+	Status string   `pos:"query:status"`
+	Tags   []string `pos:"query:tag"`
+	Note   string   `json:"note"`
+}
+
+type listFilteredArgs struct {
+	Filter  *filterParams  `json:"filter"`
+	Sort    **filterParams `json:"-"`
+	Session *EmbeddedID
+	Next    *listFilteredArgs `json:"next"`
+}
+
+func TestBindPointedStruct(t *testing.T) {
+	assert.Nil(t, Register(&listFilteredArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?status=active&tag=a&id=1", nil)
+	a := listFilteredArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, &filterParams{Status: "active", Tags: []string{"a"}}, a.Filter)
+	assert.Equal(t, "active", (**a.Sort).Status)
+	assert.Equal(t, &EmbeddedID{ID: "1"}, a.Session)
+	assert.Nil(t, a.Next)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users?id=1", strings.NewReader(`{"filter": {"note": "n"}}`))
+	a = listFilteredArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, &filterParams{Note: "n"}, a.Filter)
+	assert.Nil(t, a.Sort)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users?status=active", nil)
+	assert.ErrorIs(t, Bind(req, &listFilteredArgs{}), ErrRequired)
+}
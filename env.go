@@ -0,0 +1,34 @@
+package easybind
+
+import (
+	"net/http"
+	"os"
+)
+
+// optionDefault the value bound when the parameter is absent, e.g. `pos:"env:PORT,default=8080"`
+const optionDefault = "default"
+
+// BindEnv binds the environment variables of the env fields of params, e.g. `pos:"env:PORT,default=8080"`,
+// without any request, so deployment configuration shares the tags of params structs.
+func BindEnv(params interface{}) error {
+	return DefaultBinder.BindEnv(params)
+}
+
+// BindEnv same as BindEnv, by the configuration of b.
+func (b *Binder) BindEnv(params interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		return err
+	}
+
+	return b.Bind(req, params)
+}
+
+// lookupEnv looks up the environment variable key by b.LookupEnv.
+func (b *Binder) lookupEnv(key string) (string, bool) {
+	if b.LookupEnv != nil {
+		return b.LookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
@@ -0,0 +1,45 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deployConfig struct {
+	Port     int      `pos:"env:PORT,default=8080"`
+	Database string   `pos:"env:DATABASE_URL,required"`
+	Regions  []string `pos:"env:REGIONS,split"`
+	Page     int      `pos:"query:page,default=1"`
+}
+
+func TestBindEnv(t *testing.T) {
+	env := map[string]string{"DATABASE_URL": "postgres://db", "REGIONS": "eu, us"}
+	b := &Binder{LookupEnv: func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}}
+
+	c := deployConfig{}
+	assert.Nil(t, b.BindEnv(&c))
+	assert.Equal(t, deployConfig{Port: 8080, Database: "postgres://db", Regions: []string{"eu", "us"}, Page: 1}, c)
+
+	env["PORT"] = "9090"
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/items?page=3", nil)
+	c = deployConfig{}
+	assert.Nil(t, b.Bind(req, &c))
+	assert.Equal(t, 9090, c.Port)
+	assert.Equal(t, 3, c.Page)
+
+	delete(env, "DATABASE_URL")
+	assert.ErrorIs(t, b.BindEnv(&deployConfig{}), ErrRequired)
+
+	t.Setenv("EASYBIND_TEST_PORT", "7070")
+	type osConfig struct {
+		Port int `pos:"env:EASYBIND_TEST_PORT"`
+	}
+	o := osConfig{}
+	assert.Nil(t, BindEnv(&o))
+	assert.Equal(t, 7070, o.Port)
+}
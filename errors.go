@@ -0,0 +1,42 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrReadOnly the client set a field tagged readonly
+	ErrReadOnly = errors.New("field is read only")
+	// ErrRequired a required field is absent
+	ErrRequired = errors.New("field is required")
+	// ErrForbiddenField the client set a field tagged scope without one of its scopes
+	ErrForbiddenField = errors.New("field is forbidden")
+	// ErrUnexpectedBody the request has a body its BodyPolicy rejects
+	ErrUnexpectedBody = errors.New("unexpected request body")
+)
+
+// BindError error binding a single field.
+type BindError struct {
+	// Field struct field name
+	Field string
+	// Source where the value came from: path, query, header, form or body
+	Source string
+	// Name parameter name in Source
+	Name string
+	// Value raw value, masked if the field is sensitive
+	Value string
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	if len(e.Value) > 0 {
+		return fmt.Sprintf("bind %s from %s:%s %q: %v", e.Field, e.Source, e.Name, e.Value, e.Err)
+	}
+
+	return fmt.Sprintf("bind %s from %s:%s: %v", e.Field, e.Source, e.Name, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
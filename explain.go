@@ -0,0 +1,190 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// rules the options validating the value of a field, listed by FieldPlan.Rules.
+var rules = map[string]bool{
+	optionRequired: true, optionRequiredIf: true, optionRequiredWithout: true, optionReadOnly: true,
+	optionMaxSize: true, optionMaxTotal: true, optionMIME: true, optionAllow: true, optionFields: true, optionOps: true,
+}
+
+// FieldPlan how a field of a params struct is bound, see Explain.
+type FieldPlan struct {
+	// Field struct field name, those of nested and pointed structs prefixed by theirs, e.g. Addr.City
+	Field string
+	// Type field type
+	Type reflect.Type
+	// Source where the value is looked up: path, query, header, form, body etc.
+	Source string
+	// Name parameter name in Source, the json name for body
+	Name string
+	// Conversion converter of the values, as FieldTrace.Conversion, e.g. int, time.Time, slice of string, func ParseWindow
+	Conversion string
+	// Default value bound when the parameter is absent, see HasDefault
+	Default string
+	// HasDefault is true if the field has the default option
+	HasDefault bool
+	// Rules validation rules, e.g. required, required_if=Other, maxsize=2MB, scope=admin|owner
+	Rules []string
+	// Options every option of the tag, in order
+	Options []string
+}
+
+func (f FieldPlan) String() string {
+	s := f.Field + " <- " + f.Source + ":" + f.Name
+	if len(f.Conversion) > 0 {
+		s += " as " + f.Conversion
+	}
+
+	if f.HasDefault {
+		s += " default " + f.Default
+	}
+
+	if len(f.Rules) > 0 {
+		s += " " + strings.Join(f.Rules, tagSep)
+	}
+
+	return s
+}
+
+// Explain returns how the fields of params, a struct or a pointer to struct, are bound, in binding order,
+// e.g. to document an API, debug a tag or assert the plan in a test. It fails as Register on invalid tags.
+//
+//	plans, err := easybind.Explain(&GetUserArgs{})
+//	for _, f := range plans {
+//		fmt.Println(f) // Page <- query:page as int default 1
+//	}
+func Explain(params interface{}) ([]FieldPlan, error) {
+	return DefaultBinder.Explain(params)
+}
+
+// Explain same as Explain, by the configuration of b.
+func (b *Binder) Explain(params interface{}) ([]FieldPlan, error) {
+	typ := reflect.TypeOf(params)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, errors.New("can't bind to nonstruct value")
+	}
+
+	if err := b.registry().compile(typ).err; err != nil {
+		return nil, err
+	}
+
+	var plans []FieldPlan
+	b.explain(typ, nil, &plans)
+	return plans, nil
+}
+
+// explain appends the plans of the fields of typ, nested in nest, to plans, as bindStruct binds them.
+func (b *Binder) explain(typ reflect.Type, nest *nesting, plans *[]FieldPlan) {
+	r := b.registry()
+	for _, f := range r.compile(typ).fields {
+		fieldType := f.fieldType
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			b.explain(embedded, nest, plans)
+			continue
+		}
+
+		if f.pointed != nil {
+			b.explain(f.pointed, nest.pointed(fieldType.Name), plans)
+			continue
+		}
+
+		if nest != nil {
+			var ok bool
+			if fieldType, ok = nest.apply(b.named(fieldType)); !ok {
+				continue
+			}
+		}
+
+		fieldType = b.named(fieldType)
+		if nested, ok := nestedStruct(fieldType); ok {
+			inner := fieldType.Type
+			for inner.Kind() == reflect.Ptr {
+				inner = inner.Elem()
+			}
+			if inner.Kind() == reflect.Struct {
+				b.explain(inner, nested, plans)
+			}
+			continue
+		}
+
+		tag := ParseTag(fieldType)
+		if tag.Source == inTagBody && !isBodyPointer(tag.Name) {
+			if tag.Name = jsonName(fieldType); len(tag.Name) == 0 {
+				// json:"-", never bound
+				continue
+			}
+		}
+
+		p := FieldPlan{
+			Field:      fieldType.Name,
+			Type:       fieldType.Type,
+			Source:     tag.Source,
+			Name:       tag.Name,
+			Conversion: r.describeField(fieldType, tag),
+			Options:    tag.Options,
+		}
+		p.Default, p.HasDefault = tag.Get(optionDefault)
+		for _, option := range tag.Options {
+			if rules[strings.SplitN(option, "=", 2)[0]] {
+				p.Rules = append(p.Rules, option)
+			}
+		}
+		if scope := fieldType.Tag.Get(tagNameScope); len(scope) > 0 {
+			p.Rules = append(p.Rules, tagNameScope+"="+strings.Join(strings.Split(scope, tagSep), optionFieldSep))
+		}
+
+		*plans = append(*plans, p)
+	}
+}
+
+// describeField describes the conversion of the values of fieldType, tagged tag, as bindField traces it.
+func (r *Registry) describeField(fieldType reflect.StructField, tag Tag) string {
+	typ := fieldType.Type
+	_, hasType := r.TypeBinders[typ]
+	fn, hasFn := tag.Get(optionFunc)
+	factory, hasFactory := tag.Get(optionFactory)
+
+	switch {
+	case hasFactory:
+		return optionFactory + " " + factory
+	case tag.Source == inTagBody && isBodyMap(fieldType):
+		return "json object"
+	case tag.Source == inTagBody:
+		return "json"
+	case tag.Source == inTagForm && isFile(typ):
+		return "file"
+	case isWildcard(tag.Name), (tag.Source == inTagQuery || tag.Source == inTagForm) && r.isParamMap(typ) && !hasFn:
+		return typ.String()
+	case tag.Source == inTagQuery && typ == filterConditionsType:
+		return "filter conditions"
+	case (tag.Source == inTagQuery || tag.Source == inTagForm) && r.isStructSlice(typ) && !hasFn:
+		return "rows"
+	case tag.Source == inTagQuery && typ == sparseFieldsType:
+		return "sparse fieldsets"
+	case hasFn:
+		return optionFunc + " " + fn
+	case isBytes(typ) && !hasType && tag.Has(optionBase64):
+		return "base64"
+	case typ.Kind() == reflect.Slice && !hasType:
+		if elem := r.describeBinder(typ.Elem()); len(elem) > 0 {
+			return "slice of " + elem
+		}
+	case typ.Kind() == reflect.Array && !hasType:
+		if elem := r.describeBinder(typ.Elem()); len(elem) > 0 {
+			return "array of " + elem
+		}
+	default:
+		return r.describeBinder(typ)
+	}
+
+	return ""
+}
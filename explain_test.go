@@ -0,0 +1,45 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	type paging struct {
+		Page int `pos:"query:page,default=1"`
+	}
+	type address struct {
+		City string `pos:"query:city,trim"`
+	}
+	type args struct {
+		paging
+		UserID   string     `pos:"path,required"`
+		Since    *time.Time `pos:"query:since,required_without=Until"`
+		Tags     []string   `pos:"header:X-Tag,split"`
+		Addr     address    `pos:"query,prefix=addr_"`
+		Name     string     `json:"name" scope:"admin,owner"`
+		Internal string     `json:"-"`
+	}
+
+	plans, err := (&Binder{FieldName: SnakeCase}).Explain(&args{})
+	assert.Nil(t, err)
+	assert.Equal(t, []FieldPlan{
+		{Field: "Page", Type: reflect.TypeOf(0), Source: "query", Name: "page", Conversion: "int", Default: "1", HasDefault: true, Options: []string{"default=1"}},
+		{Field: "UserID", Type: reflect.TypeOf(""), Source: "path", Name: "user_id", Conversion: "string", Rules: []string{"required"}, Options: []string{"required"}},
+		{Field: "Since", Type: reflect.TypeOf(&time.Time{}), Source: "query", Name: "since", Conversion: "pointer to time.Time", Rules: []string{"required_without=Until"}, Options: []string{"required_without=Until"}},
+		{Field: "Tags", Type: reflect.TypeOf([]string{}), Source: "header", Name: "X-Tag", Conversion: "slice of string", Options: []string{"split"}},
+		{Field: "Addr.City", Type: reflect.TypeOf(""), Source: "query", Name: "addr_city", Conversion: "string", Options: []string{"trim"}},
+		{Field: "Name", Type: reflect.TypeOf(""), Source: "body", Name: "name", Conversion: "json", Rules: []string{"scope=admin|owner"}, Options: []string{}},
+	}, plans)
+	assert.Equal(t, "Page <- query:page as int default 1", plans[0].String())
+
+	_, err = Explain(&struct {
+		ID string `pos:"session:id"`
+	}{})
+	assert.True(t, errors.Is(err, ErrInvalidTag))
+}
@@ -0,0 +1,94 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// RegisterTags binds the fields of params, a struct or a pointer to struct which can't be tagged, e.g. vendored
+// or generated, as if tags, pos tags by field name, were theirs. They win over the pos tags the fields have.
+// It returns the error of Register, register them at init before binding params.
+//
+//	easybind.RegisterTags(&pb.GetUserRequest{}, map[string]string{"Id": "path:id,required", "View": "query:view"})
+func RegisterTags(params interface{}, tags map[string]string) error {
+	return defaultRegistry.RegisterTags(params, tags)
+}
+
+// RegisterTags same as RegisterTags, in r.
+func (r *Registry) RegisterTags(params interface{}, tags map[string]string) error {
+	typ := reflect.TypeOf(params)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return errors.New("can't bind to nonstruct value")
+	}
+
+	for name := range tags {
+		if field, ok := typ.FieldByName(name); !ok || len(field.Index) > 1 {
+			return fmt.Errorf("%w of %s.%s: no such field", ErrInvalidTag, typ, name)
+		}
+	}
+
+	r.externalTags.Store(typ, tags)
+	// compiled again with the registered tags
+	r.plans.Delete(typ)
+	return r.compile(typ).err
+}
+
+// LoadTags registers the tags of types, as RegisterTags, from r, a json object of the tags of every type
+// by its name, e.g. {"pb.GetUserRequest": {"Id": "path:id,required"}}, so they are configured by a file.
+func LoadTags(r io.Reader, types ...interface{}) error {
+	return defaultRegistry.LoadTags(r, types...)
+}
+
+// LoadTags same as LoadTags, in reg.
+func (reg *Registry) LoadTags(r io.Reader, types ...interface{}) error {
+	var config map[string]map[string]string
+	if err := json.NewDecoder(r).Decode(&config); err != nil {
+		return err
+	}
+
+	byName := make(map[string]interface{}, len(types))
+	for _, params := range types {
+		typ := reflect.TypeOf(params)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ != nil {
+			byName[typ.String()] = params
+		}
+	}
+
+	for name, tags := range config {
+		params, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown type %s", ErrInvalidTag, name)
+		}
+
+		if err := reg.RegisterTags(params, tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// externalField returns fieldType of typ with the pos tag registered by RegisterTags, if any.
+func (r *Registry) externalField(typ reflect.Type, fieldType reflect.StructField) reflect.StructField {
+	tags, ok := r.externalTags.Load(typ)
+	if !ok {
+		return fieldType
+	}
+
+	if inTag, ok := tags.(map[string]string)[fieldType.Name]; ok {
+		// Lookup returns the first match, so the prepended tag wins.
+		fieldType.Tag = reflect.StructTag(tagNameIn+":"+strconv.Quote(inTag)+" ") + fieldType.Tag
+	}
+
+	return fieldType
+}
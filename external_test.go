@@ -0,0 +1,42 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generatedRequest stands for a generated struct which can't be tagged.
+type generatedRequest struct {
+	Id   string `json:"id,omitempty"`
+	View string `json:"view,omitempty"`
+	Note string `json:"note,omitempty"`
+}
+
+type vendoredPage struct {
+	Offset int
+	Limit  int
+}
+
+func TestRegisterTags(t *testing.T) {
+	assert.Nil(t, RegisterTags(&generatedRequest{}, map[string]string{"Id": "path:id,required", "View": "query:view"}))
+	assert.ErrorIs(t, RegisterTags(&generatedRequest{}, map[string]string{"Missing": "query:m"}), ErrInvalidTag)
+
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/items/1?view=full", strings.NewReader(`{"note": "n"}`))
+	g := generatedRequest{}
+	assert.Nil(t, Bind(req, &g, map[string]string{"id": "1"}))
+	assert.Equal(t, generatedRequest{Id: "1", View: "full", Note: "n"}, g)
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/items/1", nil)
+	assert.ErrorIs(t, Bind(req, &generatedRequest{}), ErrRequired)
+
+	config := `{"easybind.vendoredPage": {"Offset": "query:offset", "Limit": "query:limit"}}`
+	assert.ErrorIs(t, LoadTags(strings.NewReader(config)), ErrInvalidTag)
+	assert.ErrorIs(t, LoadTags(strings.NewReader(`{"easybind.vendoredPage": {"Offset": "query:offset,bogus"}}`), vendoredPage{}), ErrInvalidTag)
+	assert.Nil(t, LoadTags(strings.NewReader(config), &vendoredPage{}))
+
+	p := vendoredPage{}
+	assert.Nil(t, BindMap(map[string]string{"offset": "10", "limit": "5"}, &p))
+	assert.Equal(t, vendoredPage{Offset: 10, Limit: 5}, p)
+}
@@ -0,0 +1,56 @@
+package easybind
+
+import (
+	"reflect"
+)
+
+const optionFactory = "factory"
+
+// Factories registered factories of the concrete values of interface fields, selected per field
+// by `pos:"query:shape,factory=circle"`, the field then binds as the concrete value does:
+//
+//	easybind.Factories["circle"] = func() interface{} { return &Circle{} }
+//
+// A factory returns a pointer implementing the interface of the field. The field is left nil if nothing
+// was bound into it. Json bodies may select the concrete type by a property instead, see Discriminators.
+// They are those of the package registry, see Registry.
+var Factories = defaultRegistry.Factories
+
+// factoryValue returns a new concrete value of the factory name of r, a pointer assignable to typ.
+func (r *Registry) factoryValue(name string, typ reflect.Type) (reflect.Value, bool) {
+	factory, ok := r.Factories[name]
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	val := reflect.ValueOf(factory())
+	if !val.IsValid() || val.Kind() != reflect.Ptr || val.IsNil() || !val.Type().AssignableTo(typ) {
+		return reflect.Value{}, false
+	}
+
+	return val, true
+}
+
+// bindFactory binds field, an interface field tagged with the factory option, as its concrete value.
+func (e *easyReq) bindFactory(field reflect.Value, fieldType reflect.StructField, factory string, nest *nesting, errCh chan error) {
+	if field.IsNil() {
+		val, ok := e.binder.registry().factoryValue(factory, field.Type())
+		if !ok {
+			e.trace.add(FieldTrace{Field: fieldType.Name, Skipped: "no factory " + factory + " for " + field.Type().String()})
+			return
+		}
+
+		field.Set(val)
+		e.mu.Lock()
+		e.embedded = append(e.embedded, field)
+		e.mu.Unlock()
+	}
+
+	concrete := field.Elem()
+	if concrete.Kind() != reflect.Ptr || concrete.IsNil() {
+		return
+	}
+
+	fieldType.Type = concrete.Type().Elem()
+	e.bindField(concrete.Elem(), fieldType, nest, errCh)
+}
@@ -0,0 +1,53 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (c *circle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type side float64
+
+func (s *side) Area() float64 { return float64(*s * *s) }
+
+func TestBindFactory(t *testing.T) {
+	Factories["circle"] = func() interface{} { return &circle{} }
+	Factories["side"] = func() interface{} { return new(side) }
+	defer delete(Factories, "circle")
+	defer delete(Factories, "side")
+
+	type drawArgs struct {
+		Square shape `pos:"query:side,factory=side"`
+		Circle shape `json:"circle" pos:"body,factory=circle"`
+	}
+	assert.Nil(t, Register(&drawArgs{}))
+
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/draw?side=2", strings.NewReader(`{"circle": {"radius": 1}}`))
+	a := drawArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, 4.0, a.Square.Area())
+	assert.Equal(t, &circle{Radius: 1}, a.Circle)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/draw", strings.NewReader(`{}`))
+	a = drawArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Nil(t, a.Square)
+	assert.Nil(t, a.Circle)
+
+	type badArgs struct {
+		Shape shape `pos:"query:shape,factory=triangle"`
+	}
+	assert.ErrorIs(t, Register(&badArgs{}), ErrInvalidTag)
+}
@@ -0,0 +1,92 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// FieldSet names of the struct fields populated from the request,
+// tells an omitted field apart from one set to its zero value.
+// The value is false when the field was explicitly set to null in a json body, e.g. `{"name": null}`.
+// Fields of embedded structs are named as promoted, without the embedded type.
+type FieldSet map[string]bool
+
+// Has reports whether field was populated from the request, null included.
+func (s FieldSet) Has(field string) bool {
+	_, ok := s[field]
+	return ok
+}
+
+// IsNull reports whether field was explicitly set to null in a json body.
+func (s FieldSet) IsNull(field string) bool {
+	notNull, ok := s[field]
+	return ok && !notNull
+}
+
+// FieldSetter implemented by params that want to know which fields were populated, e.g. for PATCH.
+type FieldSetter interface {
+	SetFieldSet(FieldSet)
+}
+
+// BindFieldSet same as Bind, but also returns the fields populated from the request.
+func BindFieldSet(req *http.Request, params interface{}, pathQueryier ...interface{}) (FieldSet, error) {
+	return DefaultBinder.BindFieldSet(req, params, pathQueryier...)
+}
+
+// BindFieldSet same as BindFieldSet, but use b's configuration.
+func (b *Binder) BindFieldSet(req *http.Request, params interface{}, pathQueryier ...interface{}) (fields FieldSet, err error) {
+	return b.bind(req, params, pathQueryier, nil)
+}
+
+// markBodyFields marks the fields of typ decoded from the body keys, matched like encoding/json does.
+// keys maps every key to false if its value is null.
+func (e *easyReq) markBodyFields(typ reflect.Type, keys map[string]bool) {
+	for _, f := range e.registry.compile(typ).fields {
+		fieldType := f.fieldType
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			e.markBodyFields(embedded, keys)
+			continue
+		}
+
+		if len(fieldType.PkgPath) > 0 {
+			continue
+		}
+
+		name := jsonName(fieldType)
+		if len(name) == 0 {
+			continue
+		}
+
+		if notNull, ok := keys[name]; ok {
+			e.setField(fieldType.Name, notNull)
+			continue
+		}
+
+		for key, notNull := range keys {
+			if strings.EqualFold(key, name) {
+				e.setField(fieldType.Name, notNull)
+				break
+			}
+		}
+	}
+}
+
+func (e *easyReq) setField(name string, notNull bool) {
+	e.mu.Lock()
+	e.fields[name] = notNull
+	e.mu.Unlock()
+}
+
+// jsonName name of the field in a json body, empty if the field is ignored.
+func jsonName(fieldType reflect.StructField) string {
+	name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return fieldType.Name
+	}
+
+	return name
+}
@@ -0,0 +1,74 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pageArgs struct {
+	Page int `pos:"query:page"`
+	Size int `json:"size"`
+}
+
+type patchUserArgs struct {
+	pageArgs
+	ID     string `pos:"path:id"`
+	Name   string `json:"name"`
+	Age    int    `json:"age"`
+	Ignore string `json:"-"`
+
+	fields FieldSet
+}
+
+func (a *patchUserArgs) SetFieldSet(fields FieldSet) {
+	a.fields = fields
+}
+
+func TestBindFieldSet(t *testing.T) {
+	body := `{"name": "", "SIZE": 10, "Ignore": "x"}`
+	req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/users/1?page=2", strings.NewReader(body))
+
+	args := patchUserArgs{}
+	fields, err := BindFieldSet(req, &args)
+	assert.Nil(t, err)
+	assert.Equal(t, FieldSet{"Page": true, "Size": true, "Name": true}, fields)
+	assert.Equal(t, fields, args.fields)
+	assert.True(t, fields.Has("Name"))
+	assert.False(t, fields.Has("Age"))
+	assert.False(t, fields.IsNull("Name"))
+	assert.Equal(t, 2, args.Page)
+	assert.Equal(t, 10, args.Size)
+}
+
+func TestApplyPatch(t *testing.T) {
+	type user struct {
+		ID   string
+		Name string
+		Age  int
+		Size int64
+	}
+
+	type patchArgs struct {
+		pageArgs
+		Name *string `json:"name"`
+		Age  *int    `json:"age"`
+	}
+
+	body := `{"name": "bob", "age": null, "size": 3}`
+	req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/users/1?page=2", strings.NewReader(body))
+
+	args := patchArgs{}
+	fields, err := BindFieldSet(req, &args)
+	assert.Nil(t, err)
+	assert.True(t, fields.Has("Age"))
+	assert.True(t, fields.IsNull("Age"))
+
+	u := user{ID: "1", Name: "alice", Age: 20}
+	assert.Nil(t, ApplyPatch(&u, &args, fields))
+	assert.Equal(t, user{ID: "1", Name: "bob", Size: 3}, u)
+
+	assert.NotNil(t, ApplyPatch(&struct{ Name int }{}, &args, fields))
+}
@@ -0,0 +1,88 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// filter options, e.g. `pos:"query:filter,fields=name|age,ops=eq|gte"`, fields is required
+const (
+	optionFields = "fields"
+	optionOps    = "ops"
+)
+
+// ErrFilterNotAllowed a filter condition on a field, or with an operator, its allowlist doesn't have.
+var ErrFilterNotAllowed = errors.New("filter not allowed")
+
+var filterConditionsType = reflect.TypeOf([]FilterCondition{})
+
+// FilterOps the operators of filter conditions, those of a field without fields option.
+var FilterOps = []string{"eq", "ne", "lt", "lte", "gt", "gte", "in", "like"}
+
+// FilterCondition a condition of a filter, e.g. filter[age][gte]=18 is {Field: age, Op: gte, Value: 18}.
+// A condition without operator, e.g. filter[name]=bob, is eq.
+type FilterCondition struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// bindFilter binds field, a []FilterCondition, from the query parameters name[field][op], checking fields and
+// operators against the fields and ops options, FilterOps if none. Conditions are sorted by field and operator,
+// the parameters checked in order, so the same request always fails the same way.
+func (e *easyReq) bindFilter(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) error {
+	var (
+		fields, hasFields = getInTagOption(fieldType, optionFields)
+		ops               = FilterOps
+		conditions        []FilterCondition
+	)
+	if allowed, ok := getInTagOption(fieldType, optionOps); ok {
+		ops = strings.Split(allowed, optionFieldSep)
+	}
+
+	grouped := groupValues(e.query, name)
+	keys := make([]string, 0, len(grouped))
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		condition := FilterCondition{Field: key, Op: "eq"}
+		if i := strings.Index(key, "]["); i >= 0 {
+			condition.Field, condition.Op = key[:i], key[i+2:]
+		}
+
+		switch {
+		case len(condition.Field) == 0 || strings.ContainsAny(condition.Field+condition.Op, "[]"),
+			!hasFields || !contains(strings.Split(fields, optionFieldSep), condition.Field),
+			!contains(ops, condition.Op):
+			ft.Skipped = "filter " + key + " not allowed"
+			return &BindError{Field: fieldType.Name, Source: loc, Name: name + "[" + key + "]", Err: ErrFilterNotAllowed}
+		}
+
+		for _, value := range grouped[key] {
+			condition.Value = value
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if len(conditions) == 0 {
+		ft.Skipped = "no value"
+		return nil
+	}
+
+	sort.SliceStable(conditions, func(i, j int) bool {
+		if conditions[i].Field != conditions[j].Field {
+			return conditions[i].Field < conditions[j].Field
+		}
+		return conditions[i].Op < conditions[j].Op
+	})
+
+	ft.Conversion = "filter conditions"
+	e.setField(fieldType.Name, true)
+	field.Set(reflect.ValueOf(conditions))
+	return nil
+}
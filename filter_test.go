@@ -0,0 +1,44 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listPeopleArgs struct {
+	Filter []FilterCondition `pos:"query:filter,fields=name|age,ops=eq|gte|lte"`
+	Search []FilterCondition `pos:"query:q,fields=title"`
+}
+
+func TestBindFilter(t *testing.T) {
+	assert.Nil(t, Register(&listPeopleArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/people?filter[name]=bob&filter[age][gte]=18&filter[age][lte]=65&q[title][like]=go", nil)
+	a := listPeopleArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, []FilterCondition{
+		{Field: "age", Op: "gte", Value: "18"},
+		{Field: "age", Op: "lte", Value: "65"},
+		{Field: "name", Op: "eq", Value: "bob"},
+	}, a.Filter)
+	assert.Equal(t, []FilterCondition{{Field: "title", Op: "like", Value: "go"}}, a.Search)
+
+	for _, query := range []string{"filter[password][eq]=x", "filter[age][like]=1", "q[title][regex]=.*", "filter[age][gte][x]=1", "filter[][eq]=1"} {
+		req, _ = http.NewRequest(http.MethodGet, "https://hello.world/people?"+query, nil)
+		assert.ErrorIs(t, Bind(req, &listPeopleArgs{}), ErrFilterNotAllowed, query)
+	}
+
+	// the first parameter not allowed, in order, is reported
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/people?filter[zip]=1&filter[city]=x&filter[bio]=y", nil)
+	for i := 0; i < 10; i++ {
+		bindErr := &BindError{}
+		assert.ErrorAs(t, Bind(req, &listPeopleArgs{}), &bindErr)
+		assert.Equal(t, "filter[bio]", bindErr.Name)
+	}
+
+	assert.ErrorIs(t, Register(&struct {
+		Filter []FilterCondition `pos:"query:filter"`
+	}{}), ErrInvalidTag)
+}
@@ -0,0 +1,139 @@
+package easybind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidFlag a command line flag no field of the params binds, or a flag without its value.
+var ErrInvalidFlag = errors.New("invalid flag")
+
+type flagsKey struct{}
+
+// BindFlags binds args, command line arguments without the program name, into the fields of params tagged
+// `pos:"flag:name"`, so configuration structs share the conversion and checks of params structs.
+// Flags are written -name=value, -name value, or -name for a bool, with one or two dashes; the first argument
+// which isn't a flag, or --, ends them.
+//
+//	type Config struct {
+//		Addr    string `pos:"flag:addr,required"`
+//		Verbose bool   `pos:"flag:v"`
+//	}
+//
+//	err := easybind.BindFlags(os.Args[1:], &config)
+func BindFlags(args []string, params interface{}) error {
+	return DefaultBinder.BindFlags(args, params)
+}
+
+// BindFlags same as BindFlags, by the configuration of b.
+func (b *Binder) BindFlags(args []string, params interface{}) error {
+	typ := reflect.TypeOf(params)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return errors.New("can't bind to nonstruct value")
+	}
+
+	names := make(map[string]bool)
+	b.flagNames(typ, nil, names)
+	values, err := parseFlags(args, names)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.WithValue(context.Background(), flagsKey{}, values), http.MethodGet, "/", nil)
+	if err != nil {
+		return err
+	}
+
+	return b.Bind(req, params)
+}
+
+// flagValues returns the flags bound by BindFlags.
+func flagValues(req *http.Request) url.Values {
+	values, _ := req.Context().Value(flagsKey{}).(url.Values)
+	return values
+}
+
+// flagNames adds the flags the fields of typ are bound from to names, true for those of bool fields.
+func (b *Binder) flagNames(typ reflect.Type, nest *nesting, names map[string]bool) {
+	for _, f := range b.registry().compile(typ).fields {
+		fieldType := f.fieldType
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			b.flagNames(embedded, nest, names)
+			continue
+		}
+
+		if f.pointed != nil {
+			b.flagNames(f.pointed, nest.pointed(fieldType.Name), names)
+			continue
+		}
+
+		if nest != nil {
+			var ok bool
+			if fieldType, ok = nest.apply(b.named(fieldType)); !ok {
+				continue
+			}
+		}
+
+		if nested, ok := nestedStruct(fieldType); ok {
+			inner := fieldType.Type
+			for inner.Kind() == reflect.Ptr {
+				inner = inner.Elem()
+			}
+			if inner.Kind() == reflect.Struct {
+				b.flagNames(inner, nested, names)
+			}
+			continue
+		}
+
+		if loc, name := getInTagLocAndName(b.named(fieldType)); loc == inTagFlag {
+			elem := fieldType.Type
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			names[name] = elem.Kind() == reflect.Bool
+		}
+	}
+}
+
+// parseFlags returns the values of the flags of args, names are the known flags, true for bool ones.
+func parseFlags(args []string, names map[string]bool) (url.Values, error) {
+	values := url.Values{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || len(arg) < 2 || arg[0] != '-' {
+			break
+		}
+
+		name := strings.TrimPrefix(arg[1:], "-")
+		value, hasValue := "", false
+		if j := strings.Index(name, "="); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+
+		isBool, ok := names[name]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w -%s: not defined", ErrInvalidFlag, name)
+		case !hasValue && isBool:
+			value = "true"
+		case !hasValue && i+1 == len(args):
+			return nil, fmt.Errorf("%w -%s: needs a value", ErrInvalidFlag, name)
+		case !hasValue:
+			i++
+			value = args[i]
+		}
+
+		values.Add(name, value)
+	}
+
+	return values, nil
+}
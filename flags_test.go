@@ -0,0 +1,37 @@
+package easybind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dbConfig struct {
+	Host string `pos:"flag:host"`
+	Port int    `pos:"flag:port"`
+}
+
+type serverConfig struct {
+	Addr    string    `pos:"flag:addr,required"`
+	Verbose bool      `pos:"flag:v"`
+	Workers int       `pos:"flag:workers"`
+	Origins []string  `pos:"flag:origin"`
+	DB      *dbConfig `pos:"flag,prefix=db-"`
+}
+
+func TestBindFlags(t *testing.T) {
+	c := serverConfig{}
+	args := []string{"--addr", ":8080", "-v", "-workers=5", "--origin=a", "--origin", "b", "--db-port=5432", "--", "-bogus"}
+	assert.Nil(t, BindFlags(args, &c))
+	assert.Equal(t, serverConfig{
+		Addr: ":8080", Verbose: true, Workers: 5, Origins: []string{"a", "b"}, DB: &dbConfig{Port: 5432},
+	}, c)
+
+	c = serverConfig{}
+	assert.Nil(t, BindFlags([]string{"-addr=:80", "-v=false", "serve"}, &c))
+	assert.Equal(t, serverConfig{Addr: ":80"}, c)
+
+	assert.ErrorIs(t, BindFlags([]string{"-bogus"}, &serverConfig{}), ErrInvalidFlag)
+	assert.ErrorIs(t, BindFlags([]string{"-addr"}, &serverConfig{}), ErrInvalidFlag)
+	assert.ErrorIs(t, BindFlags(nil, &serverConfig{}), ErrRequired)
+}
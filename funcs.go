@@ -0,0 +1,64 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+)
+
+const optionFunc = "func"
+
+// Funcs registered functions parsing the values of single fields, selected by `pos:"query:window,func=ParseWindow"`,
+// instead of the binder of their type, which may have none, e.g. a struct:
+//
+//	easybind.Funcs["ParseWindow"] = func(value string) (interface{}, error) { return parseWindow(value) }
+//
+// The result is converted to the field type, a slice field gets the result of every value unless the result
+// is a slice itself. An error fails the binding as a *BindError. They are those of the package registry, see Registry.
+var Funcs = defaultRegistry.Funcs
+
+// hasFunc reports whether fieldType is parsed by a func, whatever its type.
+func hasFunc(fieldType reflect.StructField) bool {
+	_, ok := getInTagOption(fieldType, optionFunc)
+	return ok
+}
+
+// funcValue binds values to typ by the func name of r.
+func (r *Registry) funcValue(name string, values []string, typ reflect.Type) (reflect.Value, error) {
+	fn, ok := r.Funcs[name]
+	if !ok {
+		return reflect.Value{}, errors.New("no func " + name)
+	}
+
+	result, err := fn(values[0])
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	val := reflect.ValueOf(result)
+	if !val.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	if val.Type().ConvertibleTo(typ) {
+		return val, nil
+	}
+	if typ.Kind() != reflect.Slice {
+		return reflect.Value{}, errors.New("func " + name + " result " + val.Type().String() + " can't be converted to " + typ.String())
+	}
+
+	slice := reflect.MakeSlice(typ, 0, len(values))
+	for i, v := range values {
+		if i > 0 {
+			if result, err = fn(v); err != nil {
+				return reflect.Value{}, err
+			}
+			val = reflect.ValueOf(result)
+		}
+
+		if !val.IsValid() || !val.Type().ConvertibleTo(typ.Elem()) {
+			return reflect.Value{}, errors.New("func " + name + " result can't be converted to " + typ.Elem().String())
+		}
+		slice = reflect.Append(slice, val.Convert(typ.Elem()))
+	}
+
+	return slice, nil
+}
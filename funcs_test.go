@@ -0,0 +1,64 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timeWindow struct {
+	Last time.Duration
+}
+
+func parseWindow(value string) (interface{}, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(value, "last-"), "d"))
+	if err != nil || !strings.HasPrefix(value, "last-") {
+		return nil, errors.New("malformed window " + value)
+	}
+
+	return timeWindow{Last: time.Duration(days) * 24 * time.Hour}, nil
+}
+
+func TestBindFunc(t *testing.T) {
+	r := NewRegistry()
+	r.Funcs["ParseWindow"] = parseWindow
+	r.Funcs["Hex"] = func(value string) (interface{}, error) {
+		return strconv.ParseInt(value, 16, 64)
+	}
+	b := &Binder{Registry: r}
+
+	type args struct {
+		Window  timeWindow   `pos:"query:window,func=ParseWindow"`
+		Windows []timeWindow `pos:"query:windows,func=ParseWindow"`
+		Color   int32        `pos:"query:color,trim,func=Hex"`
+	}
+	assert.Nil(t, r.Register(&args{}))
+	assert.NotNil(t, Register(&args{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/stats?window=last-7d&windows=last-1d&windows=last-30d&color=%20ff%20", nil)
+	a := args{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, 7*24*time.Hour, a.Window.Last)
+	assert.Equal(t, []timeWindow{{Last: 24 * time.Hour}, {Last: 30 * 24 * time.Hour}}, a.Windows)
+	assert.Equal(t, int32(255), a.Color)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/stats?window=yesterday", nil)
+	var bindErr *BindError
+	assert.True(t, errors.As(b.Bind(req, &args{}), &bindErr))
+	assert.Equal(t, "Window", bindErr.Field)
+	assert.Equal(t, "yesterday", bindErr.Value)
+
+	// a result of another type fails rather than leaving the field unset
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/stats?window=ff", nil)
+	err := b.Bind(req, &struct {
+		Window timeWindow `pos:"query:window,func=Hex"`
+	}{})
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, "Window", bindErr.Field)
+	assert.EqualError(t, bindErr.Err, "func Hex result int64 can't be converted to easybind.timeWindow")
+}
@@ -0,0 +1,74 @@
+package easybind
+
+import (
+	stdjson "encoding/json"
+	"reflect"
+	"sync"
+)
+
+// generated caches the generated unmarshalers of params types, nil for types without one.
+var generated sync.Map // map[reflect.Type]func(reflect.Value, []byte) error
+
+// generatedUnmarshaler returns the unmarshaler generated for params, by easyjson or another codegen
+// implementing json.Unmarshaler, which decodes the body instead of the reflection decoder.
+// easyjson.Unmarshaler is called by reflection, so easybind doesn't depend on easyjson.
+func generatedUnmarshaler(params interface{}) (func(data []byte) error, bool) {
+	val := reflect.ValueOf(params)
+	f, ok := generated.Load(val.Type())
+	if !ok {
+		f, _ = generated.LoadOrStore(val.Type(), lookupUnmarshaler(val.Type()))
+	}
+
+	unmarshal, _ := f.(func(reflect.Value, []byte) error)
+	if unmarshal == nil {
+		return nil, false
+	}
+
+	return func(data []byte) error {
+		return unmarshal(val, data)
+	}, true
+}
+
+// lookupUnmarshaler returns the generated unmarshaler of typ, nil if it has none.
+func lookupUnmarshaler(typ reflect.Type) func(reflect.Value, []byte) error {
+	if m, ok := typ.MethodByName("UnmarshalEasyJSON"); ok && isEasyJSONUnmarshaler(m.Type) {
+		lexerType := m.Type.In(1).Elem()
+		return func(val reflect.Value, data []byte) error {
+			lexer := reflect.New(lexerType)
+			lexer.Elem().FieldByName("Data").SetBytes(data)
+			val.MethodByName("UnmarshalEasyJSON").Call([]reflect.Value{lexer})
+
+			err, _ := lexer.MethodByName("Error").Call(nil)[0].Interface().(error)
+			return err
+		}
+	}
+
+	if typ.Implements(reflect.TypeOf((*stdjson.Unmarshaler)(nil)).Elem()) {
+		return func(val reflect.Value, data []byte) error {
+			return val.Interface().(stdjson.Unmarshaler).UnmarshalJSON(data)
+		}
+	}
+
+	return nil
+}
+
+// isEasyJSONUnmarshaler reports whether m is the method type func(T, *jlexer.Lexer) of easyjson.Unmarshaler.
+func isEasyJSONUnmarshaler(m reflect.Type) bool {
+	if m.NumIn() != 2 || m.NumOut() != 0 || m.In(1).Kind() != reflect.Ptr {
+		return false
+	}
+
+	lexer := m.In(1).Elem()
+	if lexer.Name() != "Lexer" || lexer.Kind() != reflect.Struct {
+		return false
+	}
+
+	data, ok := lexer.FieldByName("Data")
+	if !ok || data.Type != reflect.TypeOf([]byte(nil)) {
+		return false
+	}
+
+	errMethod, ok := m.In(1).MethodByName("Error")
+	return ok && errMethod.Type.NumIn() == 1 && errMethod.Type.NumOut() == 1 &&
+		errMethod.Type.Out(0) == reflect.TypeOf((*error)(nil)).Elem()
+}
@@ -0,0 +1,55 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Lexer mirrors jlexer.Lexer of easyjson.
+type Lexer struct {
+	Data  []byte
+	fatal error
+}
+
+func (l *Lexer) Error() error { return l.fatal }
+
+type easyArgs struct {
+	ID   string `pos:"query:id"`
+	Name string `json:"name"`
+}
+
+func (a *easyArgs) UnmarshalEasyJSON(l *Lexer) {
+	if !strings.Contains(string(l.Data), "name") {
+		l.fatal = errors.New("no name")
+		return
+	}
+	a.Name = "generated"
+}
+
+type unmarshalerArgs struct {
+	Name string `json:"name" pos:"body,required"`
+}
+
+func (a *unmarshalerArgs) UnmarshalJSON(data []byte) error {
+	a.Name = strings.ToUpper(strings.Trim(strings.TrimPrefix(string(data), `{"name":`), `"}`))
+	return nil
+}
+
+func TestBindGenerated(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?id=1", strings.NewReader(`{"name": "bob"}`))
+	e := easyArgs{}
+	assert.Nil(t, Bind(req, &e))
+	assert.Equal(t, easyArgs{ID: "1", Name: "generated"}, e)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(`{}`))
+	assert.EqualError(t, Bind(req, &easyArgs{}), "no name")
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(`{"name":"bob"}`))
+	u := unmarshalerArgs{}
+	assert.Nil(t, Bind(req, &u))
+	assert.Equal(t, "BOB", u.Name)
+}
@@ -0,0 +1,79 @@
+package easybind
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+var (
+	// ErrGraphQLQuery a GraphQL request without query
+	ErrGraphQLQuery = errors.New("graphql request without query")
+	// ErrGraphQLVariables GraphQL variables or extensions which aren't a json object
+	ErrGraphQLVariables = errors.New("graphql variables must be a json object")
+)
+
+// GraphQLRequest parameters of a GraphQL-over-HTTP request, from the query string of a GET request
+// or the json body of a POST one, variables and extensions are json encoded in the query string.
+type GraphQLRequest struct {
+	Query         string             `json:"query" pos:"query:query"`
+	OperationName string             `json:"operationName" pos:"query:operationName"`
+	Variables     stdjson.RawMessage `json:"variables" pos:"query:variables"`
+	Extensions    stdjson.RawMessage `json:"extensions" pos:"query:extensions"`
+}
+
+// Validate checks the request has a query and its variables and extensions are json objects, or null.
+func (r *GraphQLRequest) Validate() error {
+	if len(r.Query) == 0 {
+		return ErrGraphQLQuery
+	}
+
+	for _, raw := range []stdjson.RawMessage{r.Variables, r.Extensions} {
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		var object map[string]stdjson.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return ErrGraphQLVariables
+		}
+	}
+
+	return nil
+}
+
+// DecodeVariables decodes the variables into v, e.g. a struct typing the variables of the operation.
+func (r *GraphQLRequest) DecodeVariables(v interface{}) error {
+	if len(r.Variables) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Variables, v)
+}
+
+// BindGraphQL binds the GraphQL request req and decodes its variables into variables, unless nil.
+func BindGraphQL(req *http.Request, variables interface{}) (*GraphQLRequest, error) {
+	return DefaultBinder.BindGraphQL(req, variables)
+}
+
+// BindGraphQL same as BindGraphQL, but use b's configuration.
+func (b *Binder) BindGraphQL(req *http.Request, variables interface{}) (*GraphQLRequest, error) {
+	gql := &GraphQLRequest{}
+	if err := b.Bind(req, gql); err != nil {
+		return nil, err
+	}
+
+	if variables != nil {
+		if err := gql.DecodeVariables(variables); err != nil {
+			return nil, err
+		}
+	}
+
+	return gql, nil
+}
+
+// rawMessageBinder binds a json value sent as a string, e.g. GraphQL variables in the query string.
+func rawMessageBinder(val string, typ reflect.Type) reflect.Value {
+	return reflect.ValueOf([]byte(val)).Convert(typ)
+}
@@ -0,0 +1,47 @@
+package easybind
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindGraphQL(t *testing.T) {
+	type userVars struct {
+		ID    string `json:"id"`
+		First int    `json:"first"`
+	}
+
+	query := url.Values{
+		"query":         {"query User($id: ID!) { user(id: $id) { name } }"},
+		"operationName": {"User"},
+		"variables":     {`{"id": "1", "first": 10}`},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/graphql?"+query.Encode(), nil)
+
+	var vars userVars
+	gql, err := BindGraphQL(req, &vars)
+	assert.Nil(t, err)
+	assert.Equal(t, "User", gql.OperationName)
+	assert.Equal(t, userVars{ID: "1", First: 10}, vars)
+
+	body := `{"query": "mutation { logout }", "variables": {"id": "2"}, "extensions": {"persistedQuery": {"version": 1}}}`
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/graphql", strings.NewReader(body))
+	vars = userVars{}
+	gql, err = BindGraphQL(req, &vars)
+	assert.Nil(t, err)
+	assert.Equal(t, "mutation { logout }", gql.Query)
+	assert.Equal(t, userVars{ID: "2"}, vars)
+	assert.JSONEq(t, `{"persistedQuery": {"version": 1}}`, string(gql.Extensions))
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/graphql?variables=%7B%7D", nil)
+	_, err = BindGraphQL(req, nil)
+	assert.Equal(t, ErrGraphQLQuery, err)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/graphql?query=%7Bme%7D&variables=1", nil)
+	_, err = BindGraphQL(req, nil)
+	assert.Equal(t, ErrGraphQLVariables, err)
+}
@@ -0,0 +1,219 @@
+package easybind
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	optionReadOnly = "readonly"
+
+	tagNameScope = "scope"
+)
+
+type scopesKey struct{}
+
+// WithScopes returns a copy of ctx carrying the caller's scopes, bind a request with this context
+// so fields tagged `scope:"admin"` are only set by callers granted one of the listed scopes.
+func WithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext returns the scopes set by WithScopes.
+func ScopesFromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// guard returns why the client may not set the field, nil if it may.
+// drop reports whether the binder ignores the value instead of failing.
+func (e *easyReq) guard(fieldType reflect.StructField) (drop bool, err error) {
+	fieldType = profiled(fieldType, e.profile)
+	if hasInTagOption(fieldType, optionReadOnly) {
+		return e.binder.DropReadOnly, ErrReadOnly
+	}
+
+	required := fieldType.Tag.Get(tagNameScope)
+	if len(required) == 0 {
+		return false, nil
+	}
+
+	for _, scope := range ScopesFromContext(e.req.Context()) {
+		for _, r := range strings.Split(required, tagSep) {
+			if strings.TrimSpace(r) == scope {
+				return false, nil
+			}
+		}
+	}
+
+	return e.binder.DropForbidden, ErrForbiddenField
+}
+
+// guardBody checks the members of data, the json body of params of type typ, against the fields they set at any depth:
+// those the client may not set fail the binding, or are removed from the returned body if the binder drops them.
+func (e *easyReq) guardBody(data []byte, typ reflect.Type) ([]byte, error) {
+	if !e.hasGuards(typ, map[reflect.Type]bool{}) {
+		return data, nil
+	}
+
+	if err := e.binder.checkJSONLimits(data); err != nil {
+		return nil, err
+	}
+
+	body, err := decodeDocument(data)
+	if err != nil {
+		// reported by the decoding of params
+		return data, nil
+	}
+
+	dropped, err := e.guardValue(body, typ, "", "")
+	if err != nil || !dropped {
+		return data, err
+	}
+
+	return json.Marshal(body)
+}
+
+// hasGuards reports whether typ has fields guarded by the readonly option or the scope tag, at any depth.
+func (e *easyReq) hasGuards(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if hasInTagOption(profiled(fieldType, e.profile), optionReadOnly) || len(fieldType.Tag.Get(tagNameScope)) > 0 ||
+			e.hasGuards(fieldType.Type, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// guardValue checks the members of value, decoded into typ, field and name being their paths in params and in the body.
+// dropped reports whether members were removed.
+func (e *easyReq) guardValue(value interface{}, typ reflect.Type, field, name string) (dropped bool, err error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return false, nil
+		}
+		for i, elem := range v {
+			d, err := e.guardValue(elem, typ.Elem(), field+"."+strconv.Itoa(i), name+"."+strconv.Itoa(i))
+			if err != nil {
+				return false, err
+			}
+			dropped = dropped || d
+		}
+	case map[string]interface{}:
+		switch typ.Kind() {
+		case reflect.Map:
+			for key, elem := range v {
+				d, err := e.guardValue(elem, typ.Elem(), field+"."+key, name+"."+key)
+				if err != nil {
+					return false, err
+				}
+				dropped = dropped || d
+			}
+		case reflect.Struct:
+			return e.guardObject(v, typ, field, name)
+		}
+	}
+
+	return dropped, nil
+}
+
+// guardObject checks the members of object, decoded into typ, a struct type, as guardValue.
+func (e *easyReq) guardObject(object map[string]interface{}, typ reflect.Type, field, name string) (dropped bool, err error) {
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if embedded, ok := embeddedStruct(fieldType); ok && len(strings.Split(fieldType.Tag.Get("json"), ",")[0]) == 0 {
+			// promoted into the same object
+			d, err := e.guardObject(object, embedded, field, name)
+			if err != nil {
+				return false, err
+			}
+			dropped = dropped || d
+			continue
+		}
+
+		if tag := ParseTag(profiled(fieldType, e.profile)); len(field) == 0 && tag.Source == inTagBody && isBodyPointer(tag.Name) {
+			d, err := e.guardPointer(object, fieldType, tag.Name)
+			if err != nil {
+				return false, err
+			}
+			dropped = dropped || d
+			continue
+		}
+
+		jsonKey := jsonName(fieldType)
+		if len(fieldType.PkgPath) > 0 || len(jsonKey) == 0 {
+			continue
+		}
+
+		// matched case insensitively, as by the decoder
+		for key, member := range object {
+			if !strings.EqualFold(key, jsonKey) {
+				continue
+			}
+
+			memberField, memberName := fieldType.Name, key
+			if len(field) > 0 {
+				memberField, memberName = field+"."+memberField, name+"."+memberName
+			}
+
+			if drop, guardErr := e.guard(fieldType); guardErr != nil {
+				if !drop {
+					return false, &BindError{Field: memberField, Source: inTagBody, Name: memberName, Err: guardErr}
+				}
+				delete(object, key)
+				dropped = true
+				continue
+			}
+
+			d, err := e.guardValue(member, fieldType.Type, memberField, memberName)
+			if err != nil {
+				return false, err
+			}
+			dropped = dropped || d
+		}
+	}
+
+	return dropped, nil
+}
+
+// guardPointer checks the value referenced in body by pointer, decoded into fieldType, as guardObject.
+func (e *easyReq) guardPointer(body map[string]interface{}, fieldType reflect.StructField, pointer string) (dropped bool, err error) {
+	drop, guardErr := e.guard(fieldType)
+	if guardErr == nil {
+		return false, nil
+	}
+
+	p, err := parsePointer(pointer)
+	if err != nil {
+		return false, nil
+	}
+
+	if _, err = p.get(body); err != nil {
+		return false, nil
+	}
+
+	if !drop {
+		return false, &BindError{Field: fieldType.Name, Source: inTagBody, Name: pointer, Err: guardErr}
+	}
+
+	_, err = p.remove(body)
+	return err == nil, nil
+}
@@ -0,0 +1,117 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type updateUserArgs struct {
+	ID      string `json:"id" pos:"body,readonly"`
+	Name    string `json:"name"`
+	Version int    `pos:"query:version,readonly"`
+}
+
+func TestBindReadOnly(t *testing.T) {
+	newReq := func(url, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+		return req
+	}
+
+	args := updateUserArgs{ID: "1"}
+	err := Bind(newReq("https://hello.world/users", `{"id": "2", "name": "bob"}`), &args)
+	assert.True(t, errors.Is(err, ErrReadOnly))
+	bindErr := &BindError{}
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, "ID", bindErr.Field)
+
+	err = Bind(newReq("https://hello.world/users?version=2", `{"name": "bob"}`), &args)
+	assert.True(t, errors.Is(err, ErrReadOnly))
+
+	b := &Binder{DropReadOnly: true}
+	args = updateUserArgs{ID: "1"}
+	fields, err := b.BindFieldSet(newReq("https://hello.world/users?version=2", `{"id": "2", "name": "bob"}`), &args)
+	assert.Nil(t, err)
+	assert.Equal(t, updateUserArgs{ID: "1", Name: "bob"}, args)
+	assert.Equal(t, FieldSet{"Name": true}, fields)
+}
+
+func TestBindScope(t *testing.T) {
+	type args struct {
+		Name string `json:"name"`
+		Role string `json:"role" scope:"admin,owner"`
+	}
+
+	newReq := func(scopes ...string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, "https://hello.world/users", strings.NewReader(`{"name": "bob", "role": "admin"}`))
+		return req.WithContext(WithScopes(req.Context(), scopes...))
+	}
+
+	a := args{}
+	assert.Nil(t, Bind(newReq("owner"), &a))
+	assert.Equal(t, args{Name: "bob", Role: "admin"}, a)
+
+	err := Bind(newReq("user"), &args{})
+	assert.True(t, errors.Is(err, ErrForbiddenField))
+
+	a = args{Role: "user"}
+	assert.Nil(t, (&Binder{DropForbidden: true}).Bind(newReq(), &a))
+	assert.Equal(t, args{Name: "bob", Role: "user"}, a)
+}
+
+func TestBindGuardNested(t *testing.T) {
+	type perms struct {
+		Admin bool `json:"admin"`
+	}
+	type member struct {
+		Name string `json:"name"`
+		Role string `json:"role" scope:"admin"`
+	}
+	type audit struct {
+		Reviewed bool `json:"reviewed" pos:",readonly"`
+	}
+	type args struct {
+		*audit
+		Owner struct {
+			Name string `json:"name"`
+			Role string `json:"role" pos:",readonly"`
+		} `json:"owner"`
+		Perms   *perms   `json:"perms" pos:",readonly"`
+		Members []member `json:"members"`
+	}
+
+	newReq := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, "https://hello.world/teams/1", strings.NewReader(body))
+		return req
+	}
+
+	for _, c := range []struct{ body, field, name string }{
+		{`{"owner": {"name": "bob", "role": "admin"}}`, "Owner.Role", "owner.role"},
+		{`{"OWNER": {"ROLE": "admin"}}`, "Owner.Role", "OWNER.ROLE"},
+		{`{"perms": {"admin": true}}`, "Perms", "perms"},
+		{`{"members": [{"name": "bob"}, {"name": "eve", "role": "admin"}]}`, "Members.1.Role", "members.1.role"},
+		{`{"reviewed": true}`, "Reviewed", "reviewed"},
+	} {
+		err := Bind(newReq(c.body), &args{})
+		bindErr := &BindError{}
+		if assert.ErrorAs(t, err, &bindErr, c.body) {
+			assert.Equal(t, c.field, bindErr.Field, c.body)
+			assert.Equal(t, c.name, bindErr.Name, c.body)
+		}
+	}
+
+	// dropped members never reach the values of params, shared pointees included
+	shared := &perms{}
+	a := args{Perms: shared}
+	a.Owner.Role = "user"
+	b := &Binder{DropReadOnly: true, DropForbidden: true}
+	assert.Nil(t, b.Bind(newReq(`{"owner": {"name": "bob", "role": "admin"}, "perms": {"admin": true}, "members": [{"name": "eve", "role": "admin"}], "reviewed": true}`), &a))
+	assert.Equal(t, "bob", a.Owner.Name)
+	assert.Equal(t, "user", a.Owner.Role)
+	assert.False(t, shared.Admin)
+	assert.Equal(t, []member{{Name: "eve"}}, a.Members)
+	assert.Nil(t, a.audit)
+}
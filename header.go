@@ -0,0 +1,157 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	optionSplit = "split"
+
+	// wildcard ends the name of a source bound as a whole, e.g. `pos:"header:*"`, `pos:"header:X-Custom-*"`, `pos:"cookie:*"` or `pos:"query:filter[*"`
+	wildcard = "*"
+)
+
+var (
+	headerType         = reflect.TypeOf(http.Header{})
+	stringMapType      = reflect.TypeOf(map[string]string{})
+	stringSliceMapType = reflect.TypeOf(map[string][]string{})
+)
+
+// isWildcard reports whether name binds every key starting with its prefix.
+func isWildcard(name string) bool {
+	return strings.HasSuffix(name, wildcard)
+}
+
+// filterHeader returns the headers of h whose key starts with prefix, case insensitive.
+func filterHeader(h http.Header, prefix string) http.Header {
+	prefix = strings.ToLower(prefix)
+	filtered := http.Header{}
+	for key, values := range h {
+		if strings.HasPrefix(strings.ToLower(key), prefix) {
+			filtered[key] = append([]string(nil), values...)
+		}
+	}
+
+	return filtered
+}
+
+// filterCookies returns the values of cookies whose name starts with prefix.
+func filterCookies(cookies []*http.Cookie, prefix string) map[string][]string {
+	filtered := make(map[string][]string)
+	for _, c := range cookies {
+		if strings.HasPrefix(c.Name, prefix) {
+			filtered[c.Name] = append(filtered[c.Name], c.Value)
+		}
+	}
+
+	return filtered
+}
+
+// filterValues returns the values of the parameters whose name starts with prefix.
+func filterValues(values map[string][]string, prefix string) map[string][]string {
+	filtered := make(map[string][]string)
+	for key, v := range values {
+		if strings.HasPrefix(key, prefix) {
+			filtered[key] = v
+		}
+	}
+
+	return filtered
+}
+
+// setMultiMap sets field, an http.Header, map[string][]string or map[string]string, from m.
+// map[string]string gets the first value of every key.
+func setMultiMap(field reflect.Value, m map[string][]string) bool {
+	typ := field.Type()
+	switch {
+	case typ.ConvertibleTo(stringSliceMapType) || typ.ConvertibleTo(headerType):
+		field.Set(reflect.ValueOf(m).Convert(typ))
+	case typ.ConvertibleTo(stringMapType):
+		single := make(map[string]string, len(m))
+		for key, values := range m {
+			if len(values) > 0 {
+				single[key] = values[0]
+			}
+		}
+		field.Set(reflect.ValueOf(single).Convert(typ))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// bindWildcard binds every value of loc whose name starts with the prefix of name into field.
+func (e *easyReq) bindWildcard(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) {
+	var m map[string][]string
+	switch loc {
+	case inTagHeader:
+		m = filterHeader(e.req.Header, strings.TrimSuffix(name, wildcard))
+	case inTagCookie:
+		m = filterCookies(e.req.Cookies(), strings.TrimSuffix(name, wildcard))
+	case inTagQuery:
+		m = filterValues(e.query, strings.TrimSuffix(name, wildcard))
+	default:
+		ft.Skipped = "wildcard not supported by " + loc
+		return
+	}
+
+	if len(m) == 0 {
+		ft.Skipped = "no value"
+		return
+	}
+
+	for key := range m {
+		ft.Raw = append(ft.Raw, key)
+	}
+
+	if !setMultiMap(field, m) {
+		ft.Skipped = "can't bind " + loc + " to " + field.Type().String()
+		return
+	}
+
+	ft.Conversion = field.Type().String()
+	e.setField(fieldType.Name, true)
+}
+
+// splitList splits every value by the list syntax of RFC 9110 section 5.6.1:
+// elements are separated by commas and optional white space, empty ones are ignored,
+// commas in quoted strings don't separate.
+func splitList(values []string) []string {
+	list := make([]string, 0, len(values))
+	for _, v := range values {
+		var (
+			start   int
+			quoted  bool
+			escaped bool
+		)
+
+		for i := 0; i <= len(v); i++ {
+			if i < len(v) {
+				c := v[i]
+				switch {
+				case escaped:
+					escaped = false
+					continue
+				case quoted && c == '\\':
+					escaped = true
+					continue
+				case c == '"':
+					quoted = !quoted
+					continue
+				case quoted || c != ',':
+					continue
+				}
+			}
+
+			if elem := strings.TrimSpace(v[start:i]); len(elem) > 0 {
+				list = append(list, elem)
+			}
+			start = i + 1
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,36 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// htmlFormValues returns values of the form parameter name as browsers submit them, see Binder.HTMLForms:
+// a checkbox is checked if its last value, after a hidden input's, is sent, e.g. on, but false, off or 0;
+// a multi-select also as name[]. unchecked is true for the bool field of a checkbox which isn't sent.
+func (e *easyReq) htmlFormValues(field reflect.Value, name string, values []string) (_ []string, unchecked bool) {
+	typ := field.Type()
+	if len(values) == 0 && typ.Kind() == reflect.Slice {
+		form, _ := e.postForm()
+		values = form[name+"[]"]
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if _, ok := e.registry.TypeBinders[typ]; ok || typ.Kind() != reflect.Bool {
+		return values, false
+	}
+
+	if len(values) == 0 {
+		return nil, field.Kind() == reflect.Bool
+	}
+
+	switch strings.ToLower(strings.TrimSpace(values[len(values)-1])) {
+	case "false", "off", "0":
+		return []string{"false"}, false
+	}
+
+	return []string{"true"}, false
+}
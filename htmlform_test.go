@@ -0,0 +1,41 @@
+package easybind
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type signupForm struct {
+	Email      string   `pos:"form:email"`
+	Terms      bool     `pos:"form:terms,required"`
+	Newsletter bool     `pos:"form:newsletter"`
+	Remember   *bool    `pos:"form:remember"`
+	Hidden     bool     `pos:"form:hidden"`
+	Topics     []string `pos:"form:topics"`
+	Langs      []string `pos:"form:langs"`
+}
+
+func TestBindHTMLForms(t *testing.T) {
+	b := &Binder{HTMLForms: true}
+	form := url.Values{
+		"email": {"a@b.c"}, "terms": {"on"}, "remember": {"yes"}, "hidden": {"false", "on"},
+		"topics": {"go", "web"}, "langs[]": {"en", "fr"},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/signup", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	yes := true
+	f := signupForm{Newsletter: true}
+	assert.Nil(t, b.Bind(req, &f))
+	assert.Equal(t, signupForm{
+		Email: "a@b.c", Terms: true, Remember: &yes, Hidden: true, Topics: []string{"go", "web"}, Langs: []string{"en", "fr"},
+	}, f)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/signup", strings.NewReader("email=a@b.c&hidden=false"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.ErrorIs(t, b.Bind(req, &signupForm{}), ErrRequired)
+}
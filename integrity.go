@@ -0,0 +1,141 @@
+package easybind
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrDigestMismatch the Content-MD5, Repr-Digest or Content-Digest header of a request doesn't match its body.
+	ErrDigestMismatch = errors.New("body digest mismatch")
+	// ErrSignatureMismatch the signature header of a request is missing or doesn't match its body.
+	ErrSignatureMismatch = errors.New("body signature mismatch")
+)
+
+// Signature verifies the HMAC of the body of requests sent in a header, e.g. by the senders of webhooks.
+//
+//	binder.Signature = &easybind.Signature{Header: "X-Hub-Signature-256", Prefix: "sha256=", Keys: [][]byte{secret}}
+type Signature struct {
+	// Header the header of the signature, hex or base64 encoded
+	Header string
+	// Prefix of the signature in the header, e.g. sha256=
+	Prefix string
+	// Hash of the HMAC, sha256.New if nil
+	Hash func() hash.Hash
+	// Keys any of them verifies the signature, e.g. the current and the previous secret while rotating it
+	Keys [][]byte
+}
+
+// digestAlgorithms hashes of the Repr-Digest and Content-Digest headers (RFC 9530), others are ignored.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"sha-256": sha256.New,
+	"sha-512": sha512.New,
+}
+
+// verifyBody verifies the digests and the signature of the body of req, as configured by b.
+// The body is read only if there is something to verify, and restored for the binding.
+func (b *Binder) verifyBody(req *http.Request) error {
+	digests := b.VerifyDigest && (len(req.Header.Get("Content-MD5")) > 0 ||
+		len(req.Header.Get("Repr-Digest")) > 0 || len(req.Header.Get("Content-Digest")) > 0)
+	if !digests && b.Signature == nil {
+		return nil
+	}
+
+	var data []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if data, err = b.readAll(req.Body); err != nil {
+			return err
+		}
+		restoreBody(req, data)
+	}
+
+	if digests {
+		if err := verifyDigests(req.Header, data); err != nil {
+			return err
+		}
+	}
+
+	if b.Signature != nil {
+		return b.Signature.verify(req.Header.Get(b.Signature.Header), data)
+	}
+
+	return nil
+}
+
+// verifyDigests verifies the Content-MD5, Repr-Digest and Content-Digest headers of h against data.
+func verifyDigests(h http.Header, data []byte) error {
+	if value := h.Get("Content-MD5"); len(value) > 0 {
+		sum := md5.Sum(data)
+		if value != base64.StdEncoding.EncodeToString(sum[:]) {
+			return fmt.Errorf("%w: Content-MD5", ErrDigestMismatch)
+		}
+	}
+
+	for _, name := range []string{"Repr-Digest", "Content-Digest"} {
+		for _, member := range splitList(h.Values(name)) {
+			member = strings.TrimSpace(strings.SplitN(member, ";", 2)[0])
+			kv := strings.SplitN(member, "=", 2)
+			newHash, ok := digestAlgorithms[strings.ToLower(strings.TrimSpace(kv[0]))]
+			if !ok {
+				continue
+			}
+
+			if len(kv) != 2 || len(kv[1]) < 2 || kv[1][0] != ':' || kv[1][len(kv[1])-1] != ':' {
+				return fmt.Errorf("%w: malformed %s %s", ErrDigestMismatch, name, member)
+			}
+
+			want, err := base64.StdEncoding.DecodeString(kv[1][1 : len(kv[1])-1])
+			if err != nil {
+				return fmt.Errorf("%w: malformed %s %s", ErrDigestMismatch, name, member)
+			}
+
+			sum := newHash()
+			sum.Write(data)
+			if !hmac.Equal(sum.Sum(nil), want) {
+				return fmt.Errorf("%w: %s %s", ErrDigestMismatch, name, kv[0])
+			}
+		}
+	}
+
+	return nil
+}
+
+// verify verifies value, the signature header, against data by any of the keys of s.
+func (s *Signature) verify(value string, data []byte) error {
+	if len(value) == 0 || !strings.HasPrefix(value, s.Prefix) {
+		return fmt.Errorf("%w: no %s header", ErrSignatureMismatch, s.Header)
+	}
+
+	value = strings.TrimPrefix(value, s.Prefix)
+	signature, err := hex.DecodeString(value)
+	if err != nil {
+		if signature, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("%w: malformed %s header", ErrSignatureMismatch, s.Header)
+		}
+	}
+
+	newHash := s.Hash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+
+	for _, key := range s.Keys {
+		mac := hmac.New(newHash, key)
+		mac.Write(data)
+		if hmac.Equal(mac.Sum(nil), signature) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
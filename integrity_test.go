@@ -0,0 +1,62 @@
+package easybind
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hookArgs struct {
+	Event string `json:"event"`
+	ID    int    `pos:"query:id"`
+}
+
+func TestBindIntegrity(t *testing.T) {
+	assert.Nil(t, Register(&hookArgs{}))
+
+	body := `{"event": "push"}`
+	newReq := func(header, value string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/hooks?id=1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if len(header) > 0 {
+			req.Header.Set(header, value)
+		}
+		return req
+	}
+
+	md5Sum := md5.Sum([]byte(body))
+	shaSum := sha256.Sum256([]byte(body))
+	b := &Binder{VerifyDigest: true}
+	a := hookArgs{}
+	assert.Nil(t, b.Bind(newReq("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:])), &a))
+	assert.Equal(t, hookArgs{Event: "push", ID: 1}, a)
+	assert.Nil(t, b.Bind(newReq("", ""), &hookArgs{}))
+	assert.Nil(t, b.Bind(newReq("Repr-Digest", "unixsum=:AAA=:, sha-256=:"+base64.StdEncoding.EncodeToString(shaSum[:])+":"), &hookArgs{}))
+	assert.True(t, errors.Is(b.Bind(newReq("Content-MD5", "AAAA"), &hookArgs{}), ErrDigestMismatch))
+	assert.True(t, errors.Is(b.Bind(newReq("Repr-Digest", "sha-256=:AAAA:"), &hookArgs{}), ErrDigestMismatch))
+	assert.True(t, errors.Is(b.Bind(newReq("Content-Digest", "sha-512=abc"), &hookArgs{}), ErrDigestMismatch))
+
+	secret := []byte("s3cr3t")
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	b = &Binder{Signature: &Signature{Header: "X-Hub-Signature-256", Prefix: "sha256=", Keys: [][]byte{[]byte("old"), secret}}}
+	a = hookArgs{}
+	assert.Nil(t, b.Bind(newReq("X-Hub-Signature-256", signature), &a))
+	assert.Equal(t, "push", a.Event)
+	assert.True(t, errors.Is(b.Bind(newReq("", ""), &hookArgs{}), ErrSignatureMismatch))
+	assert.True(t, errors.Is(b.Bind(newReq("X-Hub-Signature-256", "sha256=00"), &hookArgs{}), ErrSignatureMismatch))
+
+	b.Signature.Prefix = ""
+	b.Signature.Keys = [][]byte{secret}
+	assert.Nil(t, b.Bind(newReq("X-Hub-Signature-256", base64.StdEncoding.EncodeToString(mac.Sum(nil))), &hookArgs{}))
+}
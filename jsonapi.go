@@ -0,0 +1,146 @@
+package easybind
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// MediaTypeJSONAPI media type of JSON:API documents.
+const MediaTypeJSONAPI = "application/vnd.api+json"
+
+// ErrJSONAPIDocument a JSON:API request document without primary data.
+var ErrJSONAPIDocument = errors.New("json:api document without data")
+
+// JSONAPIQuery JSON:API query parameters, embed it in params:
+//
+//	type createArticleArgs struct {
+//		easybind.JSONAPIQuery
+//		Title  string `json:"title"`
+//		Author string `json:"author"` // relationship, the id of the related resource
+//	}
+type JSONAPIQuery struct {
+	// Include related resources to include, e.g. author,comments.author
+	Include []string `json:"-" pos:"query:include,split"`
+	// Fields sparse fieldsets by parameter, e.g. fields[articles]=title,body, see Fieldset
+	Fields map[string][]string `json:"-" pos:"query:fields[*"`
+}
+
+// Fieldset returns the fields of the resource type typ requested by the sparse fieldset fields[typ],
+// nil if every field is.
+func (q JSONAPIQuery) Fieldset(typ string) []string {
+	values, ok := q.Fields["fields["+typ+"]"]
+	if !ok {
+		return nil
+	}
+
+	return splitList(values)
+}
+
+// isJSONAPI reports whether req sends a JSON:API document.
+func isJSONAPI(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return strings.EqualFold(mediaType, MediaTypeJSONAPI)
+}
+
+// jsonAPIBody returns the JSON:API document data flattened as a plain object: the attributes of the
+// primary data with its id and type, and every relationship as the id, or ids, of the related resources.
+// Members of the attributes win over the others. An array of resources is flattened into an array.
+func (b *Binder) jsonAPIBody(data []byte) ([]byte, error) {
+	if err := b.checkJSONLimits(data); err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Data jsoniter.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var flat interface{}
+	switch {
+	case len(doc.Data) == 0:
+		return nil, ErrJSONAPIDocument
+	case doc.Data[0] == '[':
+		var resources []jsonAPIResource
+		if err := json.Unmarshal(doc.Data, &resources); err != nil {
+			return nil, err
+		}
+
+		objects := make([]map[string]jsoniter.RawMessage, 0, len(resources))
+		for _, r := range resources {
+			objects = append(objects, r.flatten())
+		}
+		flat = objects
+	default:
+		var r jsonAPIResource
+		if err := json.Unmarshal(doc.Data, &r); err != nil {
+			return nil, err
+		}
+		flat = r.flatten()
+	}
+
+	return json.Marshal(flat)
+}
+
+type jsonAPIResource struct {
+	ID            jsoniter.RawMessage                       `json:"id"`
+	Type          jsoniter.RawMessage                       `json:"type"`
+	Attributes    map[string]jsoniter.RawMessage            `json:"attributes"`
+	Relationships map[string]map[string]jsoniter.RawMessage `json:"relationships"`
+}
+
+func (r jsonAPIResource) flatten() map[string]jsoniter.RawMessage {
+	flat := make(map[string]jsoniter.RawMessage, len(r.Attributes)+len(r.Relationships)+2)
+	for name, rel := range r.Relationships {
+		data, ok := rel["data"]
+		if !ok {
+			continue
+		}
+
+		if len(data) == 0 || data[0] != '[' {
+			flat[name] = relatedID(data)
+			continue
+		}
+
+		var linkages []jsoniter.RawMessage
+		if err := json.Unmarshal(data, &linkages); err != nil {
+			continue
+		}
+
+		ids := make([]jsoniter.RawMessage, 0, len(linkages))
+		for _, l := range linkages {
+			ids = append(ids, relatedID(l))
+		}
+		flat[name], _ = json.Marshal(ids)
+	}
+
+	if len(r.ID) > 0 {
+		flat["id"] = r.ID
+	}
+	if len(r.Type) > 0 {
+		flat["type"] = r.Type
+	}
+
+	for name, value := range r.Attributes {
+		flat[name] = value
+	}
+
+	return flat
+}
+
+// relatedID returns the id of a resource linkage, null for an empty to-one relationship.
+func relatedID(linkage jsoniter.RawMessage) jsoniter.RawMessage {
+	var l struct {
+		ID jsoniter.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(linkage, &l); err != nil || len(l.ID) == 0 {
+		return jsoniter.RawMessage("null")
+	}
+
+	return l.ID
+}
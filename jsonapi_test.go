@@ -0,0 +1,56 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindJSONAPI(t *testing.T) {
+	type args struct {
+		JSONAPIQuery
+		ID       string   `json:"id"`
+		Title    string   `json:"title"`
+		Author   string   `json:"author"`
+		Tags     []string `json:"tags"`
+		Reviewer *string  `json:"reviewer"`
+	}
+
+	body := `{
+		"data": {
+			"type": "articles",
+			"id": "1",
+			"attributes": {"title": "Rails is Omakase"},
+			"relationships": {
+				"author": {"data": {"type": "people", "id": "9"}},
+				"tags": {"data": [{"type": "tags", "id": "2"}, {"type": "tags", "id": "3"}]},
+				"reviewer": {"data": null}
+			}
+		}
+	}`
+
+	b := *DefaultBinder
+	b.JSONAPI = true
+
+	req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/articles/1?include=author,tags&fields[articles]=title,author&fields[people]=name", strings.NewReader(body))
+	req.Header.Set("Content-Type", MediaTypeJSONAPI)
+
+	a := args{}
+	fields, err := b.bind(req, &a, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", a.ID)
+	assert.Equal(t, "Rails is Omakase", a.Title)
+	assert.Equal(t, "9", a.Author)
+	assert.Equal(t, []string{"2", "3"}, a.Tags)
+	assert.True(t, fields.IsNull("Reviewer"))
+	assert.Equal(t, []string{"author", "tags"}, a.Include)
+	assert.Equal(t, []string{"title", "author"}, a.Fieldset("articles"))
+	assert.Equal(t, []string{"name"}, a.Fieldset("people"))
+	assert.Nil(t, a.Fieldset("tags"))
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/articles", strings.NewReader(`{"meta": {}}`))
+	req.Header.Set("Content-Type", MediaTypeJSONAPI+"; ext=bulk")
+	assert.Equal(t, ErrJSONAPIDocument, b.Bind(req, &args{}))
+}
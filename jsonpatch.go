@@ -0,0 +1,561 @@
+package easybind
+
+import (
+	"bytes"
+	"encoding"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+const (
+	// MediaTypeMergePatch media type of JSON Merge Patch documents, RFC 7386.
+	MediaTypeMergePatch = "application/merge-patch+json"
+	// MediaTypeJSONPatch media type of JSON Patch documents, RFC 6902.
+	MediaTypeJSONPatch = "application/json-patch+json"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*stdjson.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+var (
+	// ErrUnsupportedPatch a PATCH request whose body is neither a merge patch nor a json patch
+	ErrUnsupportedPatch = errors.New("unsupported patch media type")
+	// ErrPatchTestFailed the test operation of a json patch failed
+	ErrPatchTestFailed = errors.New("json patch test failed")
+)
+
+// Patch patch document of a PATCH request.
+type Patch struct {
+	// MediaType MediaTypeMergePatch or MediaTypeJSONPatch
+	MediaType string
+	// Merge merge patch document
+	Merge stdjson.RawMessage
+	// Operations json patch operations
+	Operations []PatchOperation
+}
+
+// PatchOperation operation of a json patch.
+type PatchOperation struct {
+	// Op add, remove, replace, move, copy or test
+	Op string `json:"op"`
+	// Path JSON Pointer, RFC 6901, of the target location
+	Path string `json:"path"`
+	// From JSON Pointer of the source location of move and copy
+	From string `json:"from,omitempty"`
+	// Value value of add, replace and test, required by them
+	Value stdjson.RawMessage `json:"value,omitempty"`
+}
+
+// BindPatch parses the body of req by its media type, MediaTypeMergePatch or MediaTypeJSONPatch.
+func BindPatch(req *http.Request) (*Patch, error) {
+	return DefaultBinder.BindPatch(req)
+}
+
+// BindPatch same as BindPatch, but use b's configuration.
+func (b *Binder) BindPatch(req *http.Request) (*Patch, error) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	mediaType = strings.ToLower(mediaType)
+	if mediaType != MediaTypeMergePatch && mediaType != MediaTypeJSONPatch {
+		return nil, ErrUnsupportedPatch
+	}
+
+	if req.Body == nil {
+		return nil, ErrRequired
+	}
+
+	data, err := b.readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.checkJSONLimits(data); err != nil {
+		return nil, err
+	}
+
+	patch := &Patch{MediaType: mediaType}
+	if mediaType == MediaTypeMergePatch {
+		if !json.Valid(data) {
+			return nil, errors.New("invalid merge patch document")
+		}
+
+		patch.Merge = data
+		return patch, nil
+	}
+
+	var members []map[string]stdjson.RawMessage
+	if err = json.Unmarshal(data, &patch.Operations); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for i, op := range patch.Operations {
+		if _, ok := members[i]["value"]; ok && len(op.Value) == 0 {
+			// decoded as empty, "value": null is a value though
+			op.Value = stdjson.RawMessage("null")
+			patch.Operations[i] = op
+		}
+
+		if err = op.check(); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch, nil
+}
+
+func (op PatchOperation) check() error {
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return fmt.Errorf("json patch operation %s of %s has no value", op.Op, op.Path)
+		}
+	case "remove":
+	case "move", "copy":
+		if _, err := parsePointer(op.From); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown json patch operation %q", op.Op)
+	}
+
+	_, err := parsePointer(op.Path)
+	return err
+}
+
+// Apply applies the patch to doc, a json document, and returns the patched document.
+func (p *Patch) Apply(doc []byte) ([]byte, error) {
+	target, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.MediaType {
+	case MediaTypeMergePatch:
+		patch, err := decodeDocument(p.Merge)
+		if err != nil {
+			return nil, err
+		}
+
+		target = mergePatch(target, patch)
+	case MediaTypeJSONPatch:
+		for _, op := range p.Operations {
+			if target, err = op.apply(target); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, ErrUnsupportedPatch
+	}
+
+	return json.Marshal(target)
+}
+
+// ApplyTo applies the patch to the json encoding of v, a pointer, and decodes the result back into v,
+// e.g. a model loaded from database. The fields json doesn't encode, unexported or tagged `json:"-"`, are kept.
+func (p *Patch) ApplyTo(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("can't patch to nonpointer value")
+	}
+
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if doc, err = p.Apply(doc); err != nil {
+		return err
+	}
+
+	// members removed by the patch are zero, not left as they were
+	patched := reflect.New(val.Elem().Type())
+	patched.Elem().Set(val.Elem())
+	clearJSONFields(patched.Elem())
+	if err = json.Unmarshal(doc, patched.Interface()); err != nil {
+		return err
+	}
+
+	val.Elem().Set(patched.Elem())
+	return nil
+}
+
+// clearJSONFields zeroes the fields of val, an addressable copy, that json encodes, so decoding into it
+// neither keeps the members removed by a patch nor writes to the maps and pointees it shares with the original.
+func clearJSONFields(val reflect.Value) {
+	if !val.CanSet() {
+		return
+	}
+
+	if val.Kind() != reflect.Struct || reflect.PtrTo(val.Type()).Implements(jsonUnmarshalerType) ||
+		reflect.PtrTo(val.Type()).Implements(textUnmarshalerType) {
+		val.Set(reflect.Zero(val.Type()))
+		return
+	}
+
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field, fieldType := val.Field(i), typ.Field(i)
+		if embedded, ok := embeddedStruct(fieldType); ok && len(strings.Split(fieldType.Tag.Get("json"), ",")[0]) == 0 {
+			// promoted members, the pointee is copied before it's cleared
+			if !field.CanSet() {
+				// unexported embedded structs are decoded into all the same
+				field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+			}
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					continue
+				}
+				pointee := reflect.New(embedded)
+				pointee.Elem().Set(field.Elem())
+				field.Set(pointee)
+				field = pointee.Elem()
+			}
+			clearJSONFields(field)
+			continue
+		}
+
+		if len(fieldType.PkgPath) > 0 || len(jsonName(fieldType)) == 0 {
+			continue
+		}
+
+		clearJSONFields(field)
+	}
+}
+
+// decodeDocument decodes a json document keeping numbers as written.
+func decodeDocument(data []byte) (interface{}, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// mergePatch merges patch into target as RFC 7386: null members of patch remove those of target.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// value returns the value of the operation, null if it has none.
+func (op PatchOperation) value() (interface{}, error) {
+	if len(op.Value) == 0 {
+		return nil, nil
+	}
+
+	return decodeDocument(op.Value)
+}
+
+// apply applies the operation to doc as RFC 6902 and returns the patched document.
+func (op PatchOperation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "replace" {
+			if len(path) == 0 {
+				return value, nil
+			}
+			if _, err = path.get(doc); err != nil {
+				return nil, err
+			}
+			if doc, err = path.remove(doc); err != nil {
+				return nil, err
+			}
+		}
+
+		return path.add(doc, value)
+	case "remove":
+		return path.remove(doc)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := from.get(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				return nil, fmt.Errorf("can't move %s into itself", op.From)
+			}
+			if doc, err = from.remove(doc); err != nil {
+				return nil, err
+			}
+		} else {
+			// copies don't share containers with their source
+			data, _ := json.Marshal(value)
+			if value, err = decodeDocument(data); err != nil {
+				return nil, err
+			}
+		}
+
+		return path.add(doc, value)
+	case "test":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+
+		actual, err := path.get(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if !jsonEqual(actual, value) {
+			return nil, ErrPatchTestFailed
+		}
+
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("unknown json patch operation %q", op.Op)
+}
+
+// jsonEqual reports whether two decoded json values are equal, numbers compared by value.
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case stdjson.Number:
+		bv, ok := b.(stdjson.Number)
+		if !ok {
+			return false
+		}
+
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for name, value := range av {
+			other, ok := bv[name]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return a == b
+}
+
+// jsonPointer reference tokens of a JSON Pointer, RFC 6901, empty for the whole document.
+type jsonPointer []string
+
+// parsePointer parses a JSON Pointer such as /data/attributes/name.
+func parsePointer(s string) (jsonPointer, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	if s[0] != '/' {
+		return nil, fmt.Errorf("invalid json pointer %q", s)
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// get returns the value p references in doc.
+func (p jsonPointer) get(doc interface{}) (interface{}, error) {
+	for i, token := range p {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			value, ok := v[token]
+			if !ok {
+				return nil, p.notFound(i)
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, p.notFound(i)
+			}
+			doc = v[index]
+		default:
+			return nil, p.notFound(i)
+		}
+	}
+
+	return doc, nil
+}
+
+// add adds value at p in doc, inserting it in arrays, and returns the document.
+func (p jsonPointer) add(doc, value interface{}) (interface{}, error) {
+	return p.update(doc, 0, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			if token == "-" {
+				return append(v, value), nil
+			}
+
+			index, err := arrayIndex(token, len(v))
+			if err != nil {
+				return nil, p.notFound(len(p) - 1)
+			}
+
+			v = append(v, nil)
+			copy(v[index+1:], v[index:])
+			v[index] = value
+			return v, nil
+		}
+
+		return nil, p.notFound(len(p) - 1)
+	}, value)
+}
+
+// remove removes the value at p from doc and returns the document.
+func (p jsonPointer) remove(doc interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return nil, errors.New("can't remove the whole document")
+	}
+
+	return p.update(doc, 0, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, p.notFound(len(p) - 1)
+			}
+
+			delete(v, token)
+			return v, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, p.notFound(len(p) - 1)
+			}
+
+			return append(v[:index], v[index+1:]...), nil
+		}
+
+		return nil, p.notFound(len(p) - 1)
+	}, nil)
+}
+
+// update replaces the parent of the last token of p, from the token at depth, by the result of f.
+// root replaces the whole document when p is empty.
+func (p jsonPointer) update(doc interface{}, depth int, f func(parent interface{}, token string) (interface{}, error), root interface{}) (interface{}, error) {
+	switch {
+	case len(p) == 0:
+		return root, nil
+	case depth == len(p)-1:
+		return f(doc, p[depth])
+	}
+
+	token := p[depth]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		child, ok := v[token]
+		if !ok {
+			return nil, p.notFound(depth)
+		}
+
+		child, err := p.update(child, depth+1, f, root)
+		if err != nil {
+			return nil, err
+		}
+
+		v[token] = child
+		return v, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(v)-1)
+		if err != nil {
+			return nil, p.notFound(depth)
+		}
+
+		child, err := p.update(v[index], depth+1, f, root)
+		if err != nil {
+			return nil, err
+		}
+
+		v[index] = child
+		return v, nil
+	}
+
+	return nil, p.notFound(depth)
+}
+
+func (p jsonPointer) notFound(depth int) error {
+	return fmt.Errorf("json pointer /%s not found", strings.Join(p[:depth+1], "/"))
+}
+
+// arrayIndex parses an array index token, at most max.
+func arrayIndex(token string, max int) (int, error) {
+	if len(token) > 1 && token[0] == '0' {
+		return 0, errors.New("leading zero")
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, errors.New("index out of range")
+	}
+
+	return index, nil
+}
@@ -0,0 +1,110 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindPatch(t *testing.T) {
+	newReq := func(mediaType, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/users/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", mediaType)
+		return req
+	}
+
+	patch, err := BindPatch(newReq(MediaTypeMergePatch, `{"name": "bob", "address": {"city": null}, "tags": ["b"]}`))
+	assert.Nil(t, err)
+	doc, err := patch.Apply([]byte(`{"name": "alice", "age": 20, "address": {"city": "Paris", "zip": "75"}, "tags": ["a"]}`))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"name": "bob", "age": 20, "address": {"zip": "75"}, "tags": ["b"]}`, string(doc))
+
+	patch, err = BindPatch(newReq(MediaTypeJSONPatch+"; charset=utf-8", `[
+		{"op": "test", "path": "/age", "value": 20.0},
+		{"op": "replace", "path": "/name", "value": "bob"},
+		{"op": "add", "path": "/tags/0", "value": "x"},
+		{"op": "add", "path": "/tags/-", "value": "z"},
+		{"op": "remove", "path": "/address/zip"},
+		{"op": "copy", "from": "/address", "path": "/home"},
+		{"op": "move", "from": "/tags/1", "path": "/first"},
+		{"op": "add", "path": "/a~1b", "value": null}
+	]`))
+	assert.Nil(t, err)
+	doc, err = patch.Apply([]byte(`{"name": "alice", "age": 20, "address": {"city": "Paris", "zip": "75"}, "tags": ["a"]}`))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"name": "bob", "age": 20, "address": {"city": "Paris"}, "home": {"city": "Paris"}, "tags": ["x", "z"], "first": "a", "a/b": null}`, string(doc))
+
+	type user struct {
+		Name string   `json:"name"`
+		Age  int      `json:"age"`
+		Tags []string `json:"tags"`
+	}
+	u := user{Name: "alice", Age: 20, Tags: []string{"a"}}
+	patch, _ = BindPatch(newReq(MediaTypeJSONPatch, `[{"op": "remove", "path": "/tags"}, {"op": "replace", "path": "/age", "value": 21}]`))
+	assert.Nil(t, patch.ApplyTo(&u))
+	assert.Equal(t, user{Name: "alice", Age: 21}, u)
+
+	patch, _ = BindPatch(newReq(MediaTypeJSONPatch, `[{"op": "test", "path": "/age", "value": 1}]`))
+	assert.Equal(t, ErrPatchTestFailed, patch.ApplyTo(&u))
+
+	patch, _ = BindPatch(newReq(MediaTypeJSONPatch, `[{"op": "remove", "path": "/missing/x"}]`))
+	assert.EqualError(t, patch.ApplyTo(&u), "json pointer /missing not found")
+
+	_, err = BindPatch(newReq(MediaTypeJSONPatch, `[{"op": "frobnicate", "path": "/a"}]`))
+	assert.NotNil(t, err)
+	for _, op := range []string{"add", "replace", "test"} {
+		_, err = BindPatch(newReq(MediaTypeJSONPatch, `[{"op": "`+op+`", "path": "/a"}]`))
+		assert.EqualError(t, err, "json patch operation "+op+" of /a has no value")
+	}
+	_, err = BindPatch(newReq("application/json", `{}`))
+	assert.Equal(t, ErrUnsupportedPatch, err)
+}
+
+func TestPatchApplyToKeepsHiddenFields(t *testing.T) {
+	type audit struct {
+		Note    string `json:"note"`
+		Checked bool   `json:"-"`
+	}
+	type settings struct {
+		Theme  string `json:"theme"`
+		Secret string `json:"-"`
+	}
+	type user struct {
+		*audit
+		ID           int               `json:"-"`
+		Name         string            `json:"name"`
+		Settings     settings          `json:"settings"`
+		Labels       map[string]string `json:"labels"`
+		Since        time.Time         `json:"since"`
+		passwordHash string
+	}
+
+	newReq := func(mediaType, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/users/7", strings.NewReader(body))
+		req.Header.Set("Content-Type", mediaType)
+		return req
+	}
+
+	since := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	shared := &audit{Note: "n", Checked: true}
+	labels := map[string]string{"a": "1"}
+	u := user{audit: shared, ID: 7, Name: "alice", Settings: settings{Theme: "dark", Secret: "s"}, Labels: labels, Since: since, passwordHash: "h"}
+
+	patch, err := BindPatch(newReq(MediaTypeMergePatch, `{"name": "bob", "note": "m", "labels": {"b": "2"}, "settings": {"theme": null}}`))
+	assert.Nil(t, err)
+	assert.Nil(t, patch.ApplyTo(&u))
+	assert.Equal(t, 7, u.ID)
+	assert.Equal(t, "h", u.passwordHash)
+	assert.Equal(t, "bob", u.Name)
+	assert.Equal(t, settings{Secret: "s"}, u.Settings)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, u.Labels)
+	assert.Equal(t, since, u.Since)
+	assert.Equal(t, &audit{Note: "m", Checked: true}, u.audit)
+
+	// the original maps and pointees are left untouched
+	assert.Equal(t, map[string]string{"a": "1"}, labels)
+	assert.Equal(t, &audit{Note: "n", Checked: true}, shared)
+}
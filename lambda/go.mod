@@ -0,0 +1,20 @@
+module github.com/momaek/easybind/lambda
+
+go 1.18
+
+require (
+	github.com/aws/aws-lambda-go v1.47.0
+	github.com/momaek/easybind v0.0.0
+	github.com/stretchr/testify v1.7.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/momaek/easybind => ../
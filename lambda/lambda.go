@@ -0,0 +1,125 @@
+// Package lambda binds the events of API Gateway proxy integrations into easybind params structs,
+// so AWS Lambda handlers share them with HTTP servers: path parameters, query parameters, headers,
+// cookies and base64 encoded bodies.
+//
+//	func handler(ctx context.Context, event events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+//		var args GetUserArgs
+//		if err := lambda.BindV2(ctx, event, &args); err != nil {
+//			...
+//		}
+//	}
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/momaek/easybind"
+)
+
+// PathParams path parameters of an event, the pathQueryier of easybind.Bind.
+type PathParams map[string]string
+
+// ByName returns the path parameter name.
+func (p PathParams) ByName(name string) string {
+	return p[name]
+}
+
+// Bind binds event, of a REST API or of an HTTP API with the 1.0 payload format, into params by easybind.DefaultBinder.
+// Bind the request returned by Request, with PathParams, to use another Binder.
+func Bind(ctx context.Context, event events.APIGatewayProxyRequest, params interface{}) error {
+	req, err := Request(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return easybind.Bind(req, params, PathParams(event.PathParameters))
+}
+
+// BindV2 binds event, of an HTTP API with the 2.0 payload format, into params by easybind.DefaultBinder.
+func BindV2(ctx context.Context, event events.APIGatewayV2HTTPRequest, params interface{}) error {
+	req, err := RequestV2(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return easybind.Bind(req, params, PathParams(event.PathParameters))
+}
+
+// Request returns the http request of event, of a REST API or of an HTTP API with the 1.0 payload format.
+// The multi value headers and query parameters win over the single value ones.
+func Request(ctx context.Context, event events.APIGatewayProxyRequest) (*http.Request, error) {
+	query := url.Values{}
+	for name, value := range event.QueryStringParameters {
+		query.Set(name, value)
+	}
+	for name, values := range event.MultiValueQueryStringParameters {
+		query[name] = values
+	}
+
+	req, err := newRequest(ctx, event.HTTPMethod, event.Path, query.Encode(), event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range event.Headers {
+		req.Header.Set(name, value)
+	}
+	for name, values := range event.MultiValueHeaders {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+
+	req.Host = req.Header.Get("Host")
+	req.RemoteAddr = event.RequestContext.Identity.SourceIP
+	return req, nil
+}
+
+// RequestV2 returns the http request of event, of an HTTP API with the 2.0 payload format.
+// Its cookies are sent as a Cookie header, as API Gateway removes it from the headers.
+func RequestV2(ctx context.Context, event events.APIGatewayV2HTTPRequest) (*http.Request, error) {
+	path := event.RawPath
+	if len(path) == 0 {
+		path = event.RequestContext.HTTP.Path
+	}
+
+	req, err := newRequest(ctx, event.RequestContext.HTTP.Method, path, event.RawQueryString, event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range event.Headers {
+		req.Header.Set(name, value)
+	}
+	if len(event.Cookies) > 0 {
+		req.Header.Set("Cookie", strings.Join(event.Cookies, "; "))
+	}
+
+	req.Host = req.Header.Get("Host")
+	req.RemoteAddr = event.RequestContext.HTTP.SourceIP
+	return req, nil
+}
+
+// newRequest returns a request of method to path and query, with body decoded if base64 encoded.
+func newRequest(ctx context.Context, method, path, query, body string, base64Encoded bool) (*http.Request, error) {
+	data := []byte(body)
+	if base64Encoded {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, err
+		}
+	}
+
+	u := &url.URL{Path: path, RawQuery: query}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.RequestURI = u.RequestURI()
+	return req, nil
+}
@@ -0,0 +1,55 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+type getUserArgs struct {
+	ID      string   `pos:"path:id"`
+	Fields  []string `pos:"query:field"`
+	Token   string   `pos:"header:X-Token"`
+	Session string   `pos:"cookie:session"`
+	Name    string   `json:"name"`
+}
+
+func TestBind(t *testing.T) {
+	event := events.APIGatewayProxyRequest{
+		HTTPMethod:                      "POST",
+		Path:                            "/users/1",
+		PathParameters:                  map[string]string{"id": "1"},
+		MultiValueQueryStringParameters: map[string][]string{"field": {"a", "b"}},
+		Headers: map[string]string{
+			"content-type": "application/json", "x-token": "t", "cookie": "session=s",
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte(`{"name": "bob"}`)),
+		IsBase64Encoded: true,
+	}
+
+	a := getUserArgs{}
+	assert.Nil(t, Bind(context.Background(), event, &a))
+	assert.Equal(t, getUserArgs{ID: "1", Fields: []string{"a", "b"}, Token: "t", Session: "s", Name: "bob"}, a)
+
+	event.Body = "%"
+	assert.NotNil(t, Bind(context.Background(), event, &getUserArgs{}))
+}
+
+func TestBindV2(t *testing.T) {
+	event := events.APIGatewayV2HTTPRequest{
+		RawPath:        "/users/1",
+		RawQueryString: "field=a&field=b",
+		PathParameters: map[string]string{"id": "1"},
+		Cookies:        []string{"session=s"},
+		Headers:        map[string]string{"content-type": "application/json", "x-token": "t"},
+		Body:           `{"name": "bob"}`,
+	}
+	event.RequestContext.HTTP.Method = "POST"
+
+	a := getUserArgs{}
+	assert.Nil(t, BindV2(context.Background(), event, &a))
+	assert.Equal(t, getUserArgs{ID: "1", Fields: []string{"a", "b"}, Token: "t", Session: "s", Name: "bob"}, a)
+}
@@ -0,0 +1,138 @@
+package easybind
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	// DefaultMaxDepth default max nesting depth of a json body
+	DefaultMaxDepth = 64
+	// DefaultMaxStringLength default max length of a string in a json body, 1MB
+	DefaultMaxStringLength = 1 << 20
+	// DefaultMaxElements default max members of an object or array in a json body
+	DefaultMaxElements = 100000
+	// DefaultMaxBodySize default max bytes of a body read in memory, 10MB
+	DefaultMaxBodySize = 10 << 20
+)
+
+var (
+	// ErrJSONTooDeep json body nested deeper than Binder.MaxDepth
+	ErrJSONTooDeep = errors.New("json body nested too deep")
+	// ErrJSONStringTooLong json body contains a string longer than Binder.MaxStringLength
+	ErrJSONStringTooLong = errors.New("json body string too long")
+	// ErrJSONTooManyElements json body contains an object or array with more than Binder.MaxElements members
+	ErrJSONTooManyElements = errors.New("json body has too many elements")
+	// ErrBodyTooLarge body larger than Binder.MaxBodySize
+	ErrBodyTooLarge = errors.New("body too large")
+)
+
+// decodeJSON decodes data into params, by its generated unmarshaler if any, and returns the keys of the top level object,
+// mapped to false if the value is null.
+func (b *Binder) decodeJSON(data []byte, params interface{}) (keys map[string]bool, err error) {
+	if err = b.checkJSONLimits(data); err != nil {
+		return
+	}
+
+	if b.Merge && !b.MergeZero {
+		if data, err = dropZeroMembers(data); err != nil {
+			return
+		}
+	}
+
+	if unmarshal, ok := generatedUnmarshaler(params); ok {
+		err = unmarshal(data)
+	} else {
+		err = b.Discriminators.jsonAPI().NewDecoder(bytes.NewReader(data)).Decode(params)
+	}
+	if err != nil {
+		return
+	}
+
+	keys = make(map[string]bool)
+	iter := json.BorrowIterator(data)
+	defer json.ReturnIterator(iter)
+	iter.ReadMapCB(func(it *jsoniter.Iterator, key string) bool {
+		keys[key] = it.WhatIsNext() != jsoniter.NilValue
+		it.Skip()
+		return true
+	})
+
+	return
+}
+
+// readAll reads r, failing with ErrBodyTooLarge once more than b.MaxBodySize bytes were read,
+// so checkJSONLimits never scans a body held in memory whatever its size.
+func (b *Binder) readAll(r io.Reader) ([]byte, error) {
+	if b.MaxBodySize <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, b.MaxBodySize+1))
+	if err == nil && int64(len(data)) > b.MaxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+
+	return data, err
+}
+
+// checkJSONLimits scans data without decoding it, so oversized documents are
+// rejected before the decoder allocates anything for them.
+func (b *Binder) checkJSONLimits(data []byte) error {
+	var (
+		// commas seen in each open object or array
+		commas   = make([]int, 0, 8)
+		inString bool
+		escaped  bool
+		strLen   int
+	)
+
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+				continue
+			}
+
+			strLen++
+			if b.MaxStringLength > 0 && strLen > b.MaxStringLength {
+				return ErrJSONStringTooLong
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+			strLen = 0
+		case '{', '[':
+			commas = append(commas, 0)
+			if b.MaxDepth > 0 && len(commas) > b.MaxDepth {
+				return ErrJSONTooDeep
+			}
+		case '}', ']':
+			if len(commas) > 0 {
+				commas = commas[:len(commas)-1]
+			}
+		case ',':
+			if len(commas) == 0 {
+				continue
+			}
+
+			commas[len(commas)-1]++
+			if b.MaxElements > 0 && commas[len(commas)-1]+1 > b.MaxElements {
+				return ErrJSONTooManyElements
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindJSONLimits(t *testing.T) {
+	type args struct {
+		Data interface{} `json:"data"`
+	}
+
+	b := &Binder{MaxDepth: 3, MaxStringLength: 5, MaxElements: 3}
+	cases := []struct {
+		body string
+		err  error
+	}{
+		{`{"data": [[1]]}`, nil},
+		{`{"data": [[[1]]]}`, ErrJSONTooDeep},
+		{`{"data": "hello"}`, nil},
+		{`{"data": "hello!"}`, ErrJSONStringTooLong},
+		{`{"data": "\"[[["}`, nil},
+		{`{"data": [1, 2, 3]}`, nil},
+		{`{"data": [1, 2, 3, 4]}`, ErrJSONTooManyElements},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/", strings.NewReader(c.body))
+		err := b.Bind(req, &args{})
+		assert.Equal(t, c.err, err, c.body)
+	}
+
+	b = &Binder{MaxBodySize: 16}
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/", strings.NewReader(`{"data": "hello"}`))
+	assert.Equal(t, ErrBodyTooLarge, b.Bind(req, &args{}))
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/", strings.NewReader(`{"data": "hi"}`))
+	assert.Nil(t, b.Bind(req, &args{}))
+}
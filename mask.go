@@ -0,0 +1,41 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	optionSensitive = "sensitive"
+
+	// MaskedValue replaces sensitive values in errors and traces
+	MaskedValue = "******"
+)
+
+// SensitiveNames fields whose name or parameter name contains one of these (case insensitive)
+// are treated as tagged `sensitive`.
+var SensitiveNames = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "authorization", "cookie", "credential"}
+
+func isSensitive(fieldType reflect.StructField, name string) bool {
+	if hasInTagOption(fieldType, optionSensitive) {
+		return true
+	}
+
+	fieldName, name := strings.ToLower(fieldType.Name), strings.ToLower(name)
+	for _, s := range SensitiveNames {
+		if strings.Contains(fieldName, s) || strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func maskValues(values []string) []string {
+	masked := make([]string, len(values))
+	for i := range masked {
+		masked[i] = MaskedValue
+	}
+
+	return masked
+}
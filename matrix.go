@@ -0,0 +1,30 @@
+package easybind
+
+import (
+	"net/url"
+	"strings"
+)
+
+// matrixValues returns the values of the matrix parameter name in every segment of path, an escaped path,
+// e.g. admin for role of /users;role=admin/42. Parameters without a value are left out.
+func matrixValues(path, name string) (values []string) {
+	for _, segment := range strings.Split(path, "/") {
+		params := strings.Split(segment, ";")
+		for _, param := range params[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+
+			if key, err := url.PathUnescape(kv[0]); err != nil || key != name {
+				continue
+			}
+
+			if value, err := url.PathUnescape(kv[1]); err == nil {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,22 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listCarsArgs struct {
+	Role    string   `pos:"matrix:role"`
+	Colors  []string `pos:"matrix:color,split"`
+	Year    int      `pos:"matrix:year"`
+	Version string   `pos:"matrix:v"`
+}
+
+func TestBindMatrix(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users;role=admin/cars;color=red,green;year=2012;v;color=blue%20sky/42?x=1", nil)
+	a := listCarsArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, listCarsArgs{Role: "admin", Colors: []string{"red", "green", "blue sky"}, Year: 2012}, a)
+}
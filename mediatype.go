@@ -0,0 +1,95 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	mediaTypeJSON      = "application/json"
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeMultipart = "multipart/form-data"
+)
+
+// ErrUnsupportedMediaType the body has a media type params isn't bound from, see MediaTypeError.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// MediaTypeError the Content-Type of the body isn't one params is bound from, reported if Binder.StrictContentType,
+// respond 415 Unsupported Media Type.
+type MediaTypeError struct {
+	// MediaType of the body, empty if the request has no Content-Type
+	MediaType string
+	// Expected media types of params, a type ending with + matches any suffix, e.g. application/vnd.api+json
+	Expected []string
+}
+
+func (e *MediaTypeError) Error() string {
+	return fmt.Sprintf("%v %q, want %s", ErrUnsupportedMediaType, e.MediaType, strings.Join(e.Expected, " or "))
+}
+
+func (e *MediaTypeError) Unwrap() error {
+	return ErrUnsupportedMediaType
+}
+
+// StatusCode the HTTP status code to respond with, 415.
+func (e *MediaTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// checkMediaType returns a *MediaTypeError if b.StrictContentType and req has a body whose Content-Type
+// isn't one of expected.
+func (b *Binder) checkMediaType(req *http.Request, expected ...string) error {
+	if !b.StrictContentType || len(expected) == 0 || !hasBody(req) {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	mediaType = strings.ToLower(mediaType)
+	for _, e := range expected {
+		if mediaType == e || e == mediaTypeJSON && strings.HasSuffix(mediaType, "+json") {
+			return nil
+		}
+	}
+
+	return &MediaTypeError{MediaType: mediaType, Expected: expected}
+}
+
+// structMediaTypes returns the media types of the bodies typ, a params struct, is bound from:
+// json if it has json body fields, forms if it has form fields.
+func (r *Registry) structMediaTypes(typ reflect.Type, profile string) []string {
+	var json, form bool
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		for _, f := range r.compile(typ).fields {
+			fieldType := f.fieldType
+			if embedded, ok := embeddedStruct(fieldType); ok {
+				walk(embedded)
+				continue
+			}
+
+			fieldType = profiled(fieldType, profile)
+			loc, name := getInTagLocAndName(fieldType)
+			switch {
+			case loc == inTagForm:
+				form = true
+			case len(fieldType.Tag.Get("json")) > 0 || loc == inTagBody && (isBodyMap(fieldType) || isBodyPointer(name)):
+				json = true
+			}
+		}
+	}
+	walk(typ)
+
+	var expected []string
+	if json {
+		expected = append(expected, mediaTypeJSON)
+	}
+	if form {
+		expected = append(expected, mediaTypeForm, mediaTypeMultipart)
+	}
+
+	return expected
+}
@@ -0,0 +1,51 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindStrictContentType(t *testing.T) {
+	b := *DefaultBinder
+	b.StrictContentType = true
+
+	newReq := func(contentType, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?ids=1", strings.NewReader(body))
+		if len(contentType) > 0 {
+			req.Header.Set("Content-Type", contentType)
+		}
+		return req
+	}
+
+	a := queryUsersArgs{}
+	assert.Nil(t, b.Bind(newReq("application/json; charset=utf-8", `{"age": 3}`), &a))
+	assert.Equal(t, 3, a.Age)
+	assert.Nil(t, b.Bind(newReq("application/vnd.api+json", `{"age": 3}`), &a))
+
+	err := b.Bind(newReq("text/plain", `{"age": 3}`), &a)
+	var mte *MediaTypeError
+	assert.True(t, errors.As(err, &mte))
+	assert.True(t, errors.Is(err, ErrUnsupportedMediaType))
+	assert.Equal(t, "text/plain", mte.MediaType)
+	assert.Equal(t, []string{"application/json"}, mte.Expected)
+	assert.Equal(t, http.StatusUnsupportedMediaType, mte.StatusCode())
+
+	assert.True(t, errors.Is(b.Bind(newReq("", `{"age": 3}`), &a), ErrUnsupportedMediaType))
+	assert.Nil(t, b.Bind(newReq("text/plain", ""), &a))
+
+	type formArgs struct {
+		Name string `pos:"form:name"`
+	}
+
+	f := formArgs{}
+	assert.Nil(t, b.Bind(newReq("application/x-www-form-urlencoded", "name=bob"), &f))
+	assert.Equal(t, "bob", f.Name)
+	assert.True(t, errors.Is(b.Bind(newReq("application/json", `{"name": "bob"}`), &f), ErrUnsupportedMediaType))
+
+	m := map[string]interface{}{}
+	assert.True(t, errors.Is(b.Bind(newReq("application/xml", "<a/>"), &m), ErrUnsupportedMediaType))
+}
@@ -0,0 +1,50 @@
+package easybind
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"strconv"
+)
+
+// dropZeroMembers returns data, a json body, without the members of its objects whose value is zero:
+// null, "", 0 or false, so merging it leaves the fields they would clear untouched, see Binder.Merge.
+func dropZeroMembers(data []byte) ([]byte, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		return data, nil
+	}
+
+	var object map[string]stdjson.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	for name, raw := range object {
+		switch value := bytes.TrimSpace(raw); {
+		case isZeroJSON(value):
+			delete(object, name)
+		case value[0] == '{':
+			inner, err := dropZeroMembers(value)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = inner
+		}
+	}
+
+	return json.Marshal(object)
+}
+
+// isZeroJSON reports whether value, a json value, is null, "", 0 or false.
+func isZeroJSON(value []byte) bool {
+	switch string(value) {
+	case "null", `""`, "false":
+		return true
+	}
+
+	if len(value) == 0 || value[0] != '-' && (value[0] < '0' || value[0] > '9') {
+		return false
+	}
+
+	f, err := strconv.ParseFloat(string(value), 64)
+	return err == nil && f == 0
+}
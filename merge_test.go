@@ -0,0 +1,82 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type profileRecord struct {
+	ID       string          `pos:"path:id"`
+	Name     string          `json:"name"`
+	Bio      string          `json:"bio"`
+	Age      int             `json:"age"`
+	Settings profileSettings `json:"settings"`
+	Tags     []string        `pos:"query:tag"`
+	Lang     string          `pos:"query:lang,default=en"`
+	Theme    string          `pos:"query:theme"`
+}
+
+type profileSettings struct {
+	Public bool   `json:"public"`
+	Email  string `json:"email"`
+}
+
+func TestBindMerge(t *testing.T) {
+	record := func() profileRecord {
+		return profileRecord{
+			ID: "1", Name: "bob", Bio: "hi", Age: 30, Settings: profileSettings{Public: true, Email: "bob@hello.world"},
+			Tags: []string{"a"}, Lang: "fr", Theme: "dark",
+		}
+	}
+	newReq := func(query, body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/profiles/1?"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+	pathParams := map[string]string{"id": "1"}
+
+	b := &Binder{Merge: true}
+	p := record()
+	fields, err := b.BindFieldSet(newReq("tag=b&tag=c&theme=", `{"name": "alice", "bio": "", "age": 0, "settings": {"public": false, "email": "alice@hello.world"}}`), &p, pathParams)
+	assert.Nil(t, err)
+	want := record()
+	want.Name, want.Settings.Email, want.Tags = "alice", "alice@hello.world", []string{"b", "c"}
+	assert.Equal(t, want, p)
+	assert.Equal(t, FieldSet{"ID": true, "Name": true, "Settings": true, "Tags": true}, fields)
+
+	b = &Binder{Merge: true, MergeZero: true}
+	p = record()
+	assert.Nil(t, b.Bind(newReq("theme=", `{"bio": "", "age": 0, "settings": {"public": false}}`), &p, pathParams))
+	want = record()
+	want.Bio, want.Age, want.Settings.Public, want.Theme = "", 0, false, ""
+	assert.Equal(t, want, p)
+
+	// without Merge, defaults are bound and slices appended to
+	p = record()
+	assert.Nil(t, Bind(newReq("tag=b", `{}`), &p, pathParams))
+	want = record()
+	want.Lang, want.Tags = "en", []string{"a", "b"}
+	assert.Equal(t, want, p)
+
+	// an absent checkbox leaves the field merged into as is
+	type prefs struct {
+		Public bool `pos:"form:public"`
+		Notify bool `pos:"form:notify"`
+	}
+	formReq := func(form string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/prefs", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	pr := prefs{Public: true}
+	assert.Nil(t, (&Binder{HTMLForms: true, Merge: true}).Bind(formReq("notify=on"), &pr))
+	assert.Equal(t, prefs{Public: true, Notify: true}, pr)
+
+	pr = prefs{Public: true}
+	assert.Nil(t, (&Binder{HTMLForms: true}).Bind(formReq("notify=on"), &pr))
+	assert.Equal(t, prefs{Notify: true}, pr)
+}
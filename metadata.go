@@ -0,0 +1,60 @@
+package easybind
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// metadataGatewayPrefix prefix of the http headers grpc-gateway forwards as metadata, e.g. grpcgateway-user-agent.
+const metadataGatewayPrefix = "grpcgateway-"
+
+// BindMetadata binds md, the metadata of a gRPC call, e.g. by metadata.FromIncomingContext, into the header fields
+// of params, and pathParams, the path parameters of a grpc-gateway pattern, into its path fields, so the same
+// params struct binds on both HTTP and gRPC paths.
+//
+//	md, _ := metadata.FromIncomingContext(ctx)
+//	err := easybind.BindMetadata(ctx, md, nil, &args)
+//
+// Handlers of grpc-gateway patterns bind the http request with their path parameters as usual:
+//
+//	err := easybind.Bind(req, &args, pathParams)
+func BindMetadata(ctx context.Context, md map[string][]string, pathParams map[string]string, params interface{}) error {
+	return DefaultBinder.BindMetadata(ctx, md, pathParams, params)
+}
+
+// BindMetadata same as BindMetadata, by the configuration of b.
+func (b *Binder) BindMetadata(ctx context.Context, md map[string][]string, pathParams map[string]string, params interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header = metadataHeader(md)
+	if authority := md[":authority"]; len(authority) > 0 {
+		req.Host = authority[0]
+	}
+
+	return b.Bind(req, params, pathParams)
+}
+
+// metadataHeader returns the headers of md, those forwarded by grpc-gateway under their own name
+// unless sent as metadata too. Pseudo-headers, e.g. :authority, are left out.
+func metadataHeader(md map[string][]string) http.Header {
+	header := make(http.Header, len(md))
+	for key, values := range md {
+		if name := strings.TrimPrefix(key, metadataGatewayPrefix); len(name) < len(key) {
+			if _, ok := md[name]; !ok {
+				header[http.CanonicalHeaderKey(name)] = values
+			}
+		}
+	}
+
+	for key, values := range md {
+		if !strings.HasPrefix(key, ":") && !strings.HasPrefix(key, metadataGatewayPrefix) {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+
+	return header
+}
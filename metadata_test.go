@@ -0,0 +1,36 @@
+package easybind
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getOrderArgs struct {
+	ID        string `pos:"path:id,required"`
+	Tenant    string `pos:"header:X-Tenant"`
+	UserAgent string `pos:"header:User-Agent"`
+	Host      string `pos:"request:host"`
+}
+
+func TestBindMetadata(t *testing.T) {
+	md := map[string][]string{
+		":authority":             {"orders.svc"},
+		"x-tenant":               {"acme"},
+		"grpcgateway-user-agent": {"curl"},
+		"grpcgateway-x-tenant":   {"other"},
+	}
+
+	a := getOrderArgs{}
+	assert.Nil(t, BindMetadata(context.Background(), md, map[string]string{"id": "1"}, &a))
+	assert.Equal(t, getOrderArgs{ID: "1", Tenant: "acme", UserAgent: "curl", Host: "orders.svc"}, a)
+
+	assert.ErrorIs(t, BindMetadata(context.Background(), md, nil, &getOrderArgs{}), ErrRequired)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/orders/2", nil)
+	a = getOrderArgs{}
+	assert.Nil(t, Bind(req, &a, map[string]string{"id": "2"}))
+	assert.Equal(t, "2", a.ID)
+}
@@ -0,0 +1,66 @@
+//go:build go1.18
+
+package easybind
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type paramsKey[T any] struct{}
+
+// Middleware returns net/http middleware binding the params T of every request by DefaultBinder,
+// stored in the request's context for ParamsFrom. Requests which don't bind fail with 400 Bad Request,
+// or the status of the error: 415 for MediaTypeError, 413 for ErrBodyTooLarge, 408 or 503 for TimeoutError,
+// 500 for PanicError. The message of the error is only sent with 4xx statuses, 5xx ones have their status text.
+// Path parameters are those of http.ServeMux patterns.
+//
+//	mux.Handle("GET /users/{id}", easybind.Middleware[GetUserArgs]()(http.HandlerFunc(getUser)))
+//
+//	func getUser(w http.ResponseWriter, req *http.Request) {
+//		args, _ := easybind.ParamsFrom[GetUserArgs](req.Context())
+//		...
+//	}
+func Middleware[T any]() func(http.Handler) http.Handler {
+	return BinderMiddleware[T](DefaultBinder)
+}
+
+// BinderMiddleware same as Middleware, binding by the configuration of b.
+func BinderMiddleware[T any](b *Binder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			params := new(T)
+			if err := b.Bind(req, params, req); err != nil {
+				status, msg := errorStatus(err), err.Error()
+				if status >= http.StatusInternalServerError {
+					// not the client's fault, e.g. the text of a panic, which it shouldn't see
+					msg = http.StatusText(status)
+				}
+				http.Error(w, msg, status)
+				return
+			}
+
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), paramsKey[T]{}, params)))
+		})
+	}
+}
+
+// ParamsFrom returns the params T bound by Middleware, ok is false if none were.
+func ParamsFrom[T any](ctx context.Context) (params *T, ok bool) {
+	params, ok = ctx.Value(paramsKey[T]{}).(*T)
+	return
+}
+
+// errorStatus returns the status of err if it has one, 400 Bad Request otherwise.
+func errorStatus(err error) int {
+	var status interface{ StatusCode() int }
+	switch {
+	case errors.As(err, &status):
+		return status.StatusCode()
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,77 @@
+//go:build go1.22
+
+//go:debug httpmuxgo121=0
+
+package easybind
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getAccountArgs struct {
+	ID   int    `pos:"path:id,required"`
+	Name string `json:"name"`
+}
+
+func TestMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("POST /accounts/", Middleware[getAccountArgs]()(http.NotFoundHandler()))
+	mux.Handle("POST /accounts/{id}", Middleware[getAccountArgs]()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		args, ok := ParamsFrom[getAccountArgs](req.Context())
+		assert.True(t, ok)
+		assert.Equal(t, &getAccountArgs{ID: 1, Name: "bob"}, args)
+
+		_, ok = ParamsFrom[chatMessage](req.Context())
+		assert.False(t, ok)
+	})))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/accounts/1", strings.NewReader(`{"name": "bob"}`))
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/accounts/", nil)
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	b := &Binder{StrictContentType: true}
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/accounts/1", strings.NewReader(`name=bob`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	BinderMiddleware[getAccountArgs](b)(http.NotFoundHandler()).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestMiddlewareErrorStatus(t *testing.T) {
+	r := NewRegistry()
+	r.TypeBinders[reflect.TypeOf(grumpy{})] = func(string, reflect.Type) reflect.Value {
+		panic("secret internals")
+	}
+
+	type grumpyArgs struct {
+		Grumpy grumpy `pos:"query:grumpy"`
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/grumpy?grumpy=1", nil)
+	BinderMiddleware[grumpyArgs](&Binder{Registry: r})(http.NotFoundHandler()).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "Internal Server Error\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/accounts/1", strings.NewReader(`{"name": "bobby"}`))
+	BinderMiddleware[getAccountArgs](&Binder{MaxBodySize: 4})(http.NotFoundHandler()).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	assert.Equal(t, http.StatusRequestTimeout, errorStatus(&BindError{Err: &TimeoutError{After: time.Second, Err: context.DeadlineExceeded}}))
+	assert.Equal(t, http.StatusServiceUnavailable, errorStatus(&TimeoutError{Err: context.Canceled}))
+	assert.Equal(t, http.StatusBadRequest, errorStatus(&BindError{Err: ErrRequired}))
+}
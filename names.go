@@ -0,0 +1,108 @@
+package easybind
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SnakeCase derives user_id from the field name UserID, see Binder.FieldName.
+func SnakeCase(field string) string {
+	return strings.Join(lowerWords(field), "_")
+}
+
+// KebabCase derives user-id from the field name UserID, see Binder.FieldName.
+func KebabCase(field string) string {
+	return strings.Join(lowerWords(field), "-")
+}
+
+// CamelCase derives userId from the field name UserID, see Binder.FieldName.
+func CamelCase(field string) string {
+	words := lowerWords(field)
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+
+	return strings.Join(words, "")
+}
+
+// lowerWords splits field, a Go identifier, into its lower cased words, an initialism being one word,
+// e.g. HTTPServerID into http, server and id.
+func lowerWords(field string) []string {
+	var (
+		words []string
+		runes = []rune(field)
+		start = 0
+	)
+
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		switch {
+		case cur == '_':
+		case prev == '_':
+			if start < i-1 {
+				words = append(words, string(runes[start:i-1]))
+			}
+			start = i
+		case unicode.IsUpper(cur) && !unicode.IsUpper(prev),
+			// the last capital of an initialism starts the next word, the S of HTTPServer
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if last := strings.TrimRight(string(runes[start:]), "_"); len(last) > 0 {
+		words = append(words, last)
+	}
+
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return words
+}
+
+// unnamed reports whether the pos tag of fieldType has a source but no parameter name, e.g. `pos:"query,required"`.
+func unnamed(fieldType reflect.StructField) (loc string, ok bool) {
+	inTag, tagged := fieldType.Tag.Lookup(tagNameIn)
+	if !tagged {
+		return "", false
+	}
+
+	loc = strings.TrimSpace(strings.Split(inTag, tagSep)[0])
+	if len(loc) == 0 || loc == inTagBody || strings.Contains(loc, ":") {
+		return "", false
+	}
+
+	// nested struct, its fields have the names
+	if _, nested := getInTagOption(fieldType, optionPrefix); nested {
+		return "", false
+	}
+
+	return loc, true
+}
+
+// fieldName the name of fieldType itself, without the fields nesting it.
+func fieldName(fieldType reflect.StructField) string {
+	return fieldType.Name[strings.LastIndex(fieldType.Name, ".")+1:]
+}
+
+// named returns fieldType tagged with the parameter name derived by b.FieldName if its tag has none.
+func (b *Binder) named(fieldType reflect.StructField) reflect.StructField {
+	loc, ok := unnamed(fieldType)
+	if !ok || b.FieldName == nil {
+		return fieldType
+	}
+
+	splits := strings.Split(fieldType.Tag.Get(tagNameIn), tagSep)
+	splits[0] = loc + ":" + b.FieldName(fieldName(fieldType))
+	// Lookup returns the first match, so the prepended tag wins.
+	fieldType.Tag = reflect.StructTag(tagNameIn+":"+strconv.Quote(strings.Join(splits, tagSep))+" ") + fieldType.Tag
+	return fieldType
+}
+
+// ParseTag same as ParseTag, by the configuration of b: tags without parameter name are named by b.FieldName.
+func (b *Binder) ParseTag(fieldType reflect.StructField) Tag {
+	return ParseTag(b.named(fieldType))
+}
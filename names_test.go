@@ -0,0 +1,70 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldNames(t *testing.T) {
+	for field, want := range map[string][3]string{
+		"ID":           {"id", "id", "id"},
+		"UserID":       {"user_id", "userId", "user-id"},
+		"HTTPServerID": {"http_server_id", "httpServerId", "http-server-id"},
+		"Address2Line": {"address2_line", "address2Line", "address2-line"},
+		"page_size":    {"page_size", "pageSize", "page-size"},
+	} {
+		assert.Equal(t, want, [3]string{SnakeCase(field), CamelCase(field), KebabCase(field)}, field)
+	}
+}
+
+func TestBindFieldName(t *testing.T) {
+	type address struct {
+		ZipCode string `pos:"query"`
+	}
+	type args struct {
+		UserID   string   `pos:"path,required"`
+		PageSize int      `pos:"query,default=20"`
+		TraceID  string   `pos:"header"`
+		Addr     *address `pos:"query,prefix=addr_"`
+		Sort     string   `pos:"query:order"`
+	}
+	assert.Nil(t, Register(&args{}))
+
+	newReq := func(url string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Trace-Id", "t1")
+		req.Header.Set("TraceID", "t2")
+		return req
+	}
+
+	a := args{}
+	assert.Nil(t, Bind(newReq("https://hello.world/users?PageSize=5&addr_ZipCode=75001&order=name"), &a, map[string]string{"UserID": "42"}))
+	assert.Equal(t, args{UserID: "42", PageSize: 5, TraceID: "t2", Addr: &address{ZipCode: "75001"}, Sort: "name"}, a)
+
+	b := &Binder{FieldName: KebabCase}
+	a = args{}
+	assert.Nil(t, b.Bind(newReq("https://hello.world/users?page-size=5&addr_zip-code=75001&PageSize=6"), &a, map[string]string{"user-id": "42"}))
+	assert.Equal(t, args{UserID: "42", PageSize: 5, TraceID: "t1", Addr: &address{ZipCode: "75001"}}, a)
+
+	b = &Binder{FieldName: SnakeCase}
+	err := b.Bind(newReq("https://hello.world/users"), &args{}, map[string]string{"UserID": "42"})
+	bindErr := &BindError{}
+	assert.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "user_id", bindErr.Name)
+}
+
+func TestBinderParseTag(t *testing.T) {
+	type args struct {
+		UserID string `pos:"query,required"`
+		Sort   string `pos:"query:order"`
+	}
+
+	typ := reflect.TypeOf(args{})
+	b := &Binder{FieldName: SnakeCase}
+	assert.Equal(t, Tag{Source: inTagQuery, Name: "user_id", Options: []string{optionRequired}}, b.ParseTag(typ.Field(0)))
+	assert.Equal(t, Tag{Source: inTagQuery, Name: "order", Options: []string{}}, b.ParseTag(typ.Field(1)))
+	assert.Equal(t, "UserID", DefaultBinder.ParseTag(typ.Field(0)).Name)
+}
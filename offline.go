@@ -0,0 +1,50 @@
+package easybind
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// BindValues binds values into the query and form fields of params, without any request,
+// e.g. for message consumers, command line tools and tests.
+func BindValues(values url.Values, params interface{}) error {
+	return DefaultBinder.BindValues(values, params)
+}
+
+// BindValues same as BindValues, by the configuration of b.
+func (b *Binder) BindValues(values url.Values, params interface{}) error {
+	r := &StaticRequest{
+		Verb:     http.MethodGet,
+		Target:   &url.URL{Path: "/", RawQuery: values.Encode()},
+		PostForm: values,
+	}
+
+	return b.BindRequest(r, params)
+}
+
+// BindMap same as BindValues, from a single value of every name.
+func BindMap(m map[string]string, params interface{}) error {
+	return DefaultBinder.BindMap(m, params)
+}
+
+// BindMap same as BindMap, by the configuration of b.
+func (b *Binder) BindMap(m map[string]string, params interface{}) error {
+	values := make(url.Values, len(m))
+	for name, value := range m {
+		values.Set(name, value)
+	}
+
+	return b.BindValues(values, params)
+}
+
+// BindHeaderMap binds header into the header and cookie fields of params, without any request,
+// e.g. the headers of a queue message.
+func BindHeaderMap(header http.Header, params interface{}) error {
+	return DefaultBinder.BindHeaderMap(header, params)
+}
+
+// BindHeaderMap same as BindHeaderMap, by the configuration of b.
+func (b *Binder) BindHeaderMap(header http.Header, params interface{}) error {
+	r := &StaticRequest{Verb: http.MethodGet, Target: &url.URL{Path: "/"}, Headers: header}
+	return b.BindRequest(r, params)
+}
@@ -0,0 +1,37 @@
+package easybind
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type consumeArgs struct {
+	Queue string   `pos:"query:queue,required"`
+	Tags  []string `pos:"form:tag"`
+}
+
+type messageHeaders struct {
+	TraceID string `pos:"header:X-Trace-Id"`
+	Session string `pos:"cookie:session"`
+}
+
+func TestBindValues(t *testing.T) {
+	a := consumeArgs{}
+	assert.Nil(t, BindValues(url.Values{"queue": {"jobs"}, "tag": {"a", "b"}}, &a))
+	assert.Equal(t, consumeArgs{Queue: "jobs", Tags: []string{"a", "b"}}, a)
+
+	a = consumeArgs{}
+	assert.Nil(t, BindMap(map[string]string{"queue": "jobs"}, &a))
+	assert.Equal(t, "jobs", a.Queue)
+	assert.ErrorIs(t, BindMap(nil, &consumeArgs{}), ErrRequired)
+
+	header := http.Header{}
+	header.Set("X-Trace-Id", "t1")
+	header.Set("Cookie", "session=s")
+	m := messageHeaders{}
+	assert.Nil(t, BindHeaderMap(header, &m))
+	assert.Equal(t, messageHeaders{TraceID: "t1", Session: "s"}, m)
+}
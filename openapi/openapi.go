@@ -0,0 +1,505 @@
+// Package openapi describes easybind params structs as OpenAPI 3 parameters and request bodies,
+// from their `pos` and `json` tags, as the binder reads them, defaults included, refined by `validate` tags.
+package openapi
+
+import (
+	"errors"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/momaek/easybind"
+)
+
+// Schema OpenAPI schema object, only the keywords a params struct can produce.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *uint64            `json:"minLength,omitempty"`
+	MaxLength            *uint64            `json:"maxLength,omitempty"`
+	MinItems             *uint64            `json:"minItems,omitempty"`
+	MaxItems             *uint64            `json:"maxItems,omitempty"`
+}
+
+// Parameter OpenAPI parameter object.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// MediaType OpenAPI media type object.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// RequestBody OpenAPI request body object.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Operation parameters and request body of an OpenAPI operation object.
+type Operation struct {
+	Parameters  []Parameter  `json:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+}
+
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeForm = "application/x-www-form-urlencoded"
+	// mediaTypeMultipart the media type of forms uploading files
+	mediaTypeMultipart = "multipart/form-data"
+
+	tagNameValidate = "validate"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// Describe returns the parameters and request body bound into params, a struct or a pointer to struct.
+// Fields bound from the request itself, e.g. `pos:"request:client_ip"`, aren't described.
+func Describe(params interface{}) (*Operation, error) {
+	return DescribeWith(easybind.DefaultBinder, params)
+}
+
+// DescribeWith same as Describe, for params bound by b, e.g. with the parameter names derived by b.FieldName.
+func DescribeWith(b *easybind.Binder, params interface{}) (*Operation, error) {
+	typ := reflect.TypeOf(params)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, errors.New("can't describe nonstruct value")
+	}
+
+	register := easybind.Register
+	if b.Registry != nil {
+		register = b.Registry.Register
+	}
+
+	if err := register(reflect.New(typ).Interface()); errors.Is(err, easybind.ErrTypeCycle) {
+		return nil, err
+	}
+
+	var (
+		op   = &Operation{}
+		json = &Schema{Type: "object"}
+		form = &Schema{Type: "object"}
+	)
+
+	describeFields(b, typ, "", "", op, json, form)
+
+	formType := mediaTypeForm
+	if hasFile(form) {
+		formType = mediaTypeMultipart
+	}
+
+	for mediaType, schema := range map[string]*Schema{mediaTypeJSON: json, formType: form} {
+		if len(schema.Properties) == 0 && schema.AdditionalProperties == nil {
+			continue
+		}
+
+		if op.RequestBody == nil {
+			op.RequestBody = &RequestBody{Content: map[string]MediaType{}}
+		}
+
+		op.RequestBody.Content[mediaType] = MediaType{Schema: schema}
+		op.RequestBody.Required = op.RequestBody.Required || len(schema.Required) > 0
+	}
+
+	return op, nil
+}
+
+// describeFields describes the fields of typ, those of a nested struct tagged `pos:"source,prefix=..."` if source isn't empty.
+func describeFields(b *easybind.Binder, typ reflect.Type, source, prefix string, op *Operation, json, form *Schema) {
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			describeFields(b, embedded, source, prefix, op, json, form)
+			continue
+		}
+
+		if len(fieldType.PkgPath) > 0 {
+			continue
+		}
+
+		tag := b.ParseTag(fieldType)
+		if len(source) > 0 {
+			var ok bool
+			if tag, ok = nestedTag(b, fieldType, source, prefix); !ok {
+				continue
+			}
+		}
+
+		if nested, ok := tag.Get("prefix"); ok {
+			// the fields of the struct are parameters of the source, their names prefixed
+			inner := fieldType.Type
+			for inner.Kind() == reflect.Ptr {
+				inner = inner.Elem()
+			}
+			if inner.Kind() == reflect.Struct {
+				describeFields(b, inner, tag.Source, nested, op, json, form)
+			}
+			continue
+		}
+
+		var (
+			schema   = fieldSchema(fieldType, tag)
+			required = tag.Has("required") || hasRule(fieldType, "required")
+		)
+
+		switch tag.Source {
+		case "path", "query", "header", "cookie":
+			if strings.HasSuffix(tag.Name, "*") {
+				continue
+			}
+
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     tag.Name,
+				In:       tag.Source,
+				Required: required || tag.Source == "path",
+				Schema:   schema,
+			})
+		case "form":
+			if strings.HasSuffix(tag.Name, "*") {
+				// every parameter of the form, e.g. the files of `pos:"form:*,files"`
+				if fieldType.Type.Kind() == reflect.Map {
+					schema = schema.AdditionalProperties
+				}
+				form.AdditionalProperties = schema
+				continue
+			}
+
+			addProperty(form, tag.Name, schema, required)
+		case "body":
+			if _, named := fieldType.Tag.Lookup("json"); !named && fieldType.Tag.Get("pos") == "body" && fieldType.Type.Kind() == reflect.Map {
+				// the map receives the whole body, its values are the other properties
+				json.AdditionalProperties = schema.AdditionalProperties
+				continue
+			}
+
+			if strings.HasPrefix(tag.Name, "/") {
+				// JSON Pointer, e.g. /data/attributes/name
+				addPointer(json, tag.Name, schema, required)
+				continue
+			}
+
+			name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+			switch name {
+			case "-":
+				continue
+			case "":
+				name = fieldType.Name
+			}
+
+			addProperty(json, name, schema, required)
+		}
+	}
+}
+
+// addPointer adds schema to object as the property referenced by pointer, in the objects nesting it.
+func addPointer(object *Schema, pointer string, schema *Schema, required bool) {
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if i == len(tokens)-1 {
+			addProperty(object, token, schema, required)
+			return
+		}
+
+		next, ok := object.Properties[token]
+		if !ok {
+			next = &Schema{Type: "object"}
+			addProperty(object, token, next, required)
+		} else if required && !contains(object.Required, token) {
+			object.Required = append(object.Required, token)
+		}
+		object = next
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFile reports whether object has a file property, or a property of files.
+func hasFile(object *Schema) bool {
+	isFile := func(schema *Schema) bool {
+		for ; schema != nil; schema = schema.Items {
+			if schema.Format == "binary" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, schema := range object.Properties {
+		if isFile(schema) {
+			return true
+		}
+	}
+
+	return isFile(object.AdditionalProperties)
+}
+
+// nestedTag returns the tag fieldType, a field of a nested struct bound from source, is bound by:
+// its name prefixed, its json name if it has no pos tag.
+func nestedTag(b *easybind.Binder, fieldType reflect.StructField, source, prefix string) (easybind.Tag, bool) {
+	if _, tagged := fieldType.Tag.Lookup("pos"); !tagged {
+		name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			return easybind.Tag{}, false
+		case "":
+			name = fieldType.Name
+		}
+
+		return easybind.Tag{Source: source, Name: prefix + name}, true
+	}
+
+	tag := b.ParseTag(fieldType)
+	if tag.Source == "body" {
+		return tag, true
+	}
+
+	tag.Source, tag.Name = source, prefix+tag.Name
+	options := make([]string, len(tag.Options))
+	for i, option := range tag.Options {
+		if strings.HasPrefix(option, "prefix=") {
+			option = "prefix=" + prefix + strings.TrimPrefix(option, "prefix=")
+		}
+		options[i] = option
+	}
+	tag.Options = options
+
+	return tag, true
+}
+
+func addProperty(object *Schema, name string, schema *Schema, required bool) {
+	if object.Properties == nil {
+		object.Properties = map[string]*Schema{}
+	}
+
+	object.Properties[name] = schema
+	if required {
+		object.Required = append(object.Required, name)
+	}
+}
+
+// fieldSchema returns the schema of fieldType's type refined by its validate tag and the default option of tag.
+func fieldSchema(fieldType reflect.StructField, tag easybind.Tag) *Schema {
+	schema := typeSchema(fieldType.Type, map[reflect.Type]bool{})
+	applyRules(schema, fieldType)
+
+	if def, ok := tag.Get("default"); ok {
+		schema.Default = literal(def, schema.Type)
+	}
+
+	return schema
+}
+
+// typeSchema returns the schema of typ, seen guards recursive types.
+func typeSchema(typ reflect.Type, seen map[reflect.Type]bool) *Schema {
+	switch typ {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if typ.Elem() == fileHeaderType {
+			// uploaded file, absent rather than null
+			return typeSchema(typ.Elem(), seen)
+		}
+		schema := typeSchema(typ.Elem(), seen)
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: typeSchema(typ.Elem(), seen)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(typ.Elem(), seen)}
+	case reflect.Struct:
+		schema := &Schema{Type: "object"}
+		if seen[typ] {
+			return schema
+		}
+
+		seen[typ] = true
+		defer delete(seen, typ)
+		describeObject(typ, schema, seen)
+		return schema
+	}
+
+	return &Schema{}
+}
+
+// describeObject adds the json fields of typ to schema.
+func describeObject(typ reflect.Type, schema *Schema, seen map[reflect.Type]bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			describeObject(embedded, schema, seen)
+			continue
+		}
+
+		if len(fieldType.PkgPath) > 0 {
+			continue
+		}
+
+		name := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = fieldType.Name
+		}
+
+		property := typeSchema(fieldType.Type, seen)
+		applyRules(property, fieldType)
+		addProperty(schema, name, property, hasRule(fieldType, "required"))
+	}
+}
+
+// applyRules maps the validate tag rules of go-playground/validator style, e.g. `validate:"min=1,max=10"`,
+// to schema keywords. Unknown rules are ignored.
+func applyRules(schema *Schema, fieldType reflect.StructField) {
+	for _, rule := range strings.Split(fieldType.Tag.Get(tagNameValidate), ",") {
+		kv := strings.SplitN(strings.TrimSpace(rule), "=", 2)
+		if len(kv) != 2 {
+			if kv[0] == "email" || kv[0] == "uuid" || kv[0] == "uri" {
+				schema.Format = kv[0]
+			}
+			continue
+		}
+
+		switch kv[0] {
+		case "min", "gte":
+			setBound(schema, kv[1], true)
+		case "max", "lte":
+			setBound(schema, kv[1], false)
+		case "len":
+			setBound(schema, kv[1], true)
+			setBound(schema, kv[1], false)
+		case "oneof":
+			for _, v := range strings.Fields(kv[1]) {
+				schema.Enum = append(schema.Enum, literal(v, schema.Type))
+			}
+		}
+	}
+}
+
+// setBound sets the lower or upper bound of a number, the length of a string or the count of an array.
+func setBound(schema *Schema, val string, lower bool) {
+	switch schema.Type {
+	case "integer", "number":
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return
+		}
+
+		if lower {
+			schema.Minimum = &f
+		} else {
+			schema.Maximum = &f
+		}
+	case "string", "array":
+		n, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case schema.Type == "string" && lower:
+			schema.MinLength = &n
+		case schema.Type == "string":
+			schema.MaxLength = &n
+		case lower:
+			schema.MinItems = &n
+		default:
+			schema.MaxItems = &n
+		}
+	}
+}
+
+func hasRule(fieldType reflect.StructField, rule string) bool {
+	for _, r := range strings.Split(fieldType.Tag.Get(tagNameValidate), ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literal converts a tag value to the json value of a schema of type typ.
+func literal(val, typ string) interface{} {
+	switch typ {
+	case "integer":
+		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return i
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+
+	return val
+}
+
+// embeddedStruct returns the struct type of fieldType if it embeds a struct or a pointer to struct.
+func embeddedStruct(fieldType reflect.StructField) (reflect.Type, bool) {
+	if !fieldType.Anonymous {
+		return nil, false
+	}
+
+	typ := fieldType.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ, typ.Kind() == reflect.Struct
+}
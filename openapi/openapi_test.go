@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/momaek/easybind"
+)
+
+type page struct {
+	Page int `pos:"query:page,default=1" validate:"min=1"`
+	Size int `pos:"query:size,default=20" validate:"min=1,max=100"`
+}
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type createUser struct {
+	page
+	ID       string    `pos:"path:id"`
+	Token    string    `pos:"header:Authorization,required"`
+	Sort     *string   `pos:"query:sort" validate:"oneof=name age"`
+	ClientIP string    `pos:"request:client_ip"`
+	Name     string    `json:"name" validate:"required,max=32"`
+	Birthday time.Time `json:"birthday"`
+	Address  *address  `json:"address"`
+	Tags     []string  `json:"tags" validate:"max=5"`
+	Ignored  string    `json:"-"`
+}
+
+func TestDescribe(t *testing.T) {
+	op, err := Describe(&createUser{})
+	assert.Nil(t, err)
+
+	data, _ := json.Marshal(op)
+	assert.JSONEq(t, `{
+		"parameters": [
+			{"name": "page", "in": "query", "schema": {"type": "integer", "format": "int32", "default": 1, "minimum": 1}},
+			{"name": "size", "in": "query", "schema": {"type": "integer", "format": "int32", "default": 20, "minimum": 1, "maximum": 100}},
+			{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+			{"name": "Authorization", "in": "header", "required": true, "schema": {"type": "string"}},
+			{"name": "sort", "in": "query", "schema": {"type": "string", "nullable": true, "enum": ["name", "age"]}}
+		],
+		"requestBody": {
+			"required": true,
+			"content": {
+				"application/json": {
+					"schema": {
+						"type": "object",
+						"required": ["name"],
+						"properties": {
+							"name": {"type": "string", "maxLength": 32},
+							"birthday": {"type": "string", "format": "date-time"},
+							"address": {"type": "object", "nullable": true, "required": ["city"], "properties": {"city": {"type": "string"}}},
+							"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 5}
+						}
+					}
+				}
+			}
+		}
+	}`, string(data))
+
+	op, err = Describe(&struct {
+		Name  string            `json:"name"`
+		Extra map[string]string `pos:"body"`
+	}{})
+	assert.Nil(t, err)
+	data, _ = json.Marshal(op.RequestBody.Content["application/json"].Schema)
+	assert.JSONEq(t, `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": {"type": "string"}}`, string(data))
+
+	op, err = Describe(&struct {
+		ID   string `pos:"body:/data/id"`
+		Name string `pos:"body:/data/attributes/name,required"`
+		Age  int    `pos:"body:/data/attributes/age"`
+	}{})
+	assert.Nil(t, err)
+	data, _ = json.Marshal(op.RequestBody.Content["application/json"].Schema)
+	assert.JSONEq(t, `{"type": "object", "required": ["data"], "properties": {"data": {
+		"type": "object", "required": ["attributes"], "properties": {
+			"id": {"type": "string"},
+			"attributes": {"type": "object", "required": ["name"], "properties": {
+				"name": {"type": "string"},
+				"age": {"type": "integer", "format": "int32"}
+			}}
+		}
+	}}}`, string(data))
+
+	_, err = Describe(1)
+	assert.NotNil(t, err)
+}
+
+func TestDescribeWith(t *testing.T) {
+	type args struct {
+		UserID   string `pos:"path,required"`
+		PageSize int    `pos:"query,default=20"`
+		Sort     string `pos:"query:order"`
+	}
+
+	op, err := DescribeWith(&easybind.Binder{FieldName: easybind.SnakeCase}, &args{})
+	assert.Nil(t, err)
+
+	data, _ := json.Marshal(op.Parameters)
+	assert.JSONEq(t, `[
+		{"name": "user_id", "in": "path", "required": true, "schema": {"type": "string"}},
+		{"name": "page_size", "in": "query", "schema": {"type": "integer", "format": "int32", "default": 20}},
+		{"name": "order", "in": "query", "schema": {"type": "string"}}
+	]`, string(data))
+
+	op, err = Describe(&args{})
+	assert.Nil(t, err)
+	assert.Equal(t, "UserID", op.Parameters[0].Name)
+
+	// the plan is compiled in the registry of the binder
+	r := easybind.NewRegistry()
+	_, err = DescribeWith(&easybind.Binder{Registry: r}, &args{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, r.Plans().Types)
+}
+
+func TestDescribeNested(t *testing.T) {
+	type geo struct {
+		Lat float64 `pos:"query:lat"`
+	}
+	type addr struct {
+		City string `json:"city"`
+		Zip  string `pos:"query:zip,required"`
+		Geo  geo    `pos:"query,prefix=geo_"`
+	}
+	type args struct {
+		Addr *addr `pos:"query,prefix=addr_"`
+	}
+
+	op, err := Describe(&args{})
+	assert.Nil(t, err)
+
+	data, _ := json.Marshal(op.Parameters)
+	assert.JSONEq(t, `[
+		{"name": "addr_city", "in": "query", "schema": {"type": "string"}},
+		{"name": "addr_zip", "in": "query", "required": true, "schema": {"type": "string"}},
+		{"name": "addr_geo_lat", "in": "query", "schema": {"type": "number", "format": "double"}}
+	]`, string(data))
+}
+
+func TestDescribeFiles(t *testing.T) {
+	op, err := Describe(&struct {
+		Title       string                  `pos:"form:title"`
+		Avatar      *multipart.FileHeader   `pos:"form:avatar,required"`
+		Attachments []*multipart.FileHeader `pos:"form:attachments"`
+	}{})
+	assert.Nil(t, err)
+
+	data, _ := json.Marshal(op.RequestBody)
+	assert.JSONEq(t, `{"required": true, "content": {"multipart/form-data": {"schema": {
+		"type": "object", "required": ["avatar"], "properties": {
+			"title": {"type": "string"},
+			"avatar": {"type": "string", "format": "binary"},
+			"attachments": {"type": "array", "items": {"type": "string", "format": "binary"}}
+		}
+	}}}}`, string(data))
+
+	op, err = Describe(&struct {
+		Files []*multipart.FileHeader `pos:"form:*,files"`
+	}{})
+	assert.Nil(t, err)
+	data, _ = json.Marshal(op.RequestBody.Content["multipart/form-data"].Schema)
+	assert.JSONEq(t, `{"type": "object", "additionalProperties": {"type": "array", "items": {"type": "string", "format": "binary"}}}`, string(data))
+}
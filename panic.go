@@ -0,0 +1,45 @@
+package easybind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+)
+
+// PanicError a panic recovered while binding, e.g. by a converter or a Source, the Err of a *BindError
+// so it doesn't crash the server: errors.As(err, &panicErr) returns it.
+type PanicError struct {
+	// Value the value passed to panic
+	Value interface{}
+	// Stack the stack trace of the goroutine which panicked
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// StatusCode the HTTP status code to respond with, 500.
+func (e *PanicError) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// Unwrap returns the value passed to panic if it's an error, e.g. a runtime.Error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// panicError returns the *BindError of recovered, a panic binding the field of fieldType, or params if nil.
+func panicError(recovered interface{}, fieldType *reflect.StructField, params interface{}) error {
+	err := &BindError{Err: &PanicError{Value: recovered, Stack: debug.Stack()}}
+	if fieldType != nil {
+		err.Field = fieldType.Name
+		err.Source, err.Name = getInTagLocAndName(*fieldType)
+	} else if params != nil {
+		err.Field = reflect.TypeOf(params).String()
+	}
+
+	return err
+}
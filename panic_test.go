@@ -0,0 +1,56 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type grumpy struct{}
+
+type panickySource struct{}
+
+func (panickySource) Name() string { return "panicky" }
+
+func (panickySource) Values(*http.Request, string) ([]string, error) {
+	var m map[string][]string
+	m["boom"] = nil
+	return nil, nil
+}
+
+func TestBindPanic(t *testing.T) {
+	r := NewRegistry()
+	r.TypeBinders[reflect.TypeOf(grumpy{})] = func(string, reflect.Type) reflect.Value {
+		panic("grumpy")
+	}
+	r.RegisterSource(panickySource{})
+	b := &Binder{Registry: r}
+
+	type args struct {
+		ID     int    `pos:"query:id"`
+		Grumpy grumpy `pos:"query:grumpy"`
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?id=1&grumpy=1", nil)
+	err := b.Bind(req, &args{})
+
+	var bindErr *BindError
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, "Grumpy", bindErr.Field)
+	assert.Equal(t, "query", bindErr.Source)
+	panicErr, ok := errors.Unwrap(bindErr).(*PanicError)
+	assert.True(t, ok)
+	assert.Equal(t, "grumpy", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	err = b.Bind(req, &struct {
+		Nested struct {
+			Value string `pos:"panicky:value"`
+		}
+	}{})
+	var runtimeErr runtime.Error
+	assert.True(t, errors.As(err, &runtimeErr))
+}
@@ -0,0 +1,82 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// isParamMap reports whether typ is a map with string keys whose values can be bound, e.g. map[string][]string,
+// bound from the parameters grouped by a prefix.
+func (r *Registry) isParamMap(typ reflect.Type) bool {
+	if _, ok := r.TypeBinders[typ]; ok || typ.Kind() != reflect.Map || typ.Key().Kind() != reflect.String {
+		return false
+	}
+
+	return r.hasBinder(typ.Elem())
+}
+
+// groupValues returns the values of the parameters name.key or name[key] by key, repeated ones preserved.
+func groupValues(values map[string][]string, name string) map[string][]string {
+	grouped := make(map[string][]string)
+	for param, v := range values {
+		rest := strings.TrimPrefix(param, name)
+		if len(rest) == len(param) || len(rest) < 2 {
+			continue
+		}
+
+		var key string
+		switch {
+		case rest[0] == '.':
+			key = rest[1:]
+		case rest[0] == '[' && rest[len(rest)-1] == ']':
+			key = rest[1 : len(rest)-1]
+		}
+
+		if len(key) > 0 {
+			grouped[key] = append(grouped[key], v...)
+		}
+	}
+
+	return grouped
+}
+
+// bindParamMap binds field, a map, from the query or form parameters grouped by name, e.g. attr.color=red&attr.color=blue
+// into map[string][]string{"color": {"red", "blue"}}. Slice values get every value of a key, others the first.
+func (e *easyReq) bindParamMap(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) {
+	var values map[string][]string
+	switch loc {
+	case inTagQuery:
+		values = e.query
+	case inTagForm:
+		values, _ = e.postForm()
+	}
+
+	grouped := groupValues(values, name)
+	if len(grouped) == 0 {
+		ft.Skipped = "no value"
+		return
+	}
+
+	var (
+		typ        = field.Type()
+		elem       = typ.Elem()
+		_, hasType = e.registry.TypeBinders[elem]
+		m          = reflect.MakeMapWithSize(typ, len(grouped))
+	)
+
+	for key, v := range grouped {
+		ft.Raw = append(ft.Raw, key)
+
+		var val reflect.Value
+		if elem.Kind() == reflect.Slice && !hasType {
+			val = e.registry.sliceValue(v, elem)
+		} else {
+			val = e.registry.bindValue(v[0], elem)
+		}
+		m.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), val.Convert(elem))
+	}
+
+	ft.Conversion = typ.String()
+	field.Set(m)
+	e.setField(fieldType.Name, true)
+}
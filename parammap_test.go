@@ -0,0 +1,29 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindParamMap(t *testing.T) {
+	type searchArgs struct {
+		Attrs  map[string][]string `pos:"query:attr"`
+		Limits map[string]int      `pos:"query:limit"`
+		Sizes  map[string][]int    `pos:"query:size"`
+	}
+	assert.Nil(t, Register(&searchArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/search?attr.color=red&attr.color=blue&attr[size]=xl&limit.users=10&size.a=1&size.a=2&attribute=x", nil)
+	a := searchArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, map[string][]string{"color": {"red", "blue"}, "size": {"xl"}}, a.Attrs)
+	assert.Equal(t, map[string]int{"users": 10}, a.Limits)
+	assert.Equal(t, map[string][]int{"a": {1, 2}}, a.Sizes)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/search", nil)
+	a = searchArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Nil(t, a.Attrs)
+}
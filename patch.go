@@ -0,0 +1,49 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyPatch copies the fields of src named in fields onto the fields with the same name of dst,
+// e.g. apply a PATCH request bound by BindFieldSet onto a model loaded from database.
+// Fields dst doesn't have are skipped, a pointer field of src is dereferenced when dst's isn't a pointer.
+func ApplyPatch(dst, src interface{}, fields FieldSet) error {
+	dstVal, srcVal := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return errors.New("can't patch to nonpointer value")
+	}
+
+	dstVal, srcVal = reflect.Indirect(dstVal), reflect.Indirect(srcVal)
+	if dstVal.Kind() != reflect.Struct || srcVal.Kind() != reflect.Struct {
+		return errors.New("can't patch nonstruct value")
+	}
+
+	for name := range fields {
+		from, to := srcVal.FieldByName(name), dstVal.FieldByName(name)
+		if !from.IsValid() || !to.IsValid() || !to.CanSet() {
+			continue
+		}
+
+		if from.Kind() == reflect.Ptr && to.Kind() != reflect.Ptr {
+			if from.IsNil() {
+				to.Set(reflect.Zero(to.Type()))
+				continue
+			}
+
+			from = from.Elem()
+		}
+
+		switch {
+		case from.Type().AssignableTo(to.Type()):
+			to.Set(from)
+		case from.Type().ConvertibleTo(to.Type()) && (to.Kind() != reflect.String || from.Kind() == reflect.String):
+			to.Set(from.Convert(to.Type()))
+		default:
+			return fmt.Errorf("can't patch field %s: %s to %s", name, from.Type(), to.Type())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,480 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+var (
+	// ErrInvalidTag a pos tag which can't be bound, reported by Register.
+	ErrInvalidTag = errors.New("invalid pos tag")
+	// ErrUnsupportedField an unexported field, or one of a type never bound such as funcs and channels,
+	// reported by PrecompileStrict.
+	ErrUnsupportedField = errors.New("unsupported field")
+	// ErrTypeCycle a struct embedding itself, or nesting itself by the prefix option, reported by Register.
+	// The field closing the cycle is never bound.
+	ErrTypeCycle = errors.New("recursive struct type")
+	// ErrAmbiguousField embedded structs promoting fields of the same name bound from parameters,
+	// reported by Register.
+	ErrAmbiguousField = errors.New("ambiguous field")
+)
+
+// plan binding plan of a params struct type, compiled once per type.
+type plan struct {
+	fields []fieldPlan
+	// err first invalid tag, binding ignores these fields
+	err error
+	// skipped fields never bound, embedded structs included
+	skipped []error
+	// names fields by name, promoted ones included
+	names map[string]promoted
+	// params is true if some fields are bound from parameters, pointed structs bind only those
+	params bool
+}
+
+// promoted the fields of a name at the shallowest depth of embedding.
+type promoted struct {
+	depth int
+	count int
+	// bound is true if one of them is bound from parameters
+	bound bool
+}
+
+type fieldPlan struct {
+	index     int
+	fieldType reflect.StructField
+	// pointed struct type of the field, see pointedStruct, nil if the field isn't one
+	pointed reflect.Type
+}
+
+var (
+	sources = map[string]bool{
+		inTagPath: true, inTagQuery: true, inTagHeader: true, inTagForm: true,
+		inTagCookie: true, inTagRequest: true, inTagBody: true, inTagFlag: true, inTagEnv: true,
+		inTagMatrix: true,
+	}
+
+	requestNames = map[string]bool{
+		requestClientIP: true, requestRemoteAddr: true, requestMethod: true, requestHost: true, requestPath: true,
+	}
+
+	options = map[string]bool{
+		optionRequired: true, optionReadOnly: true, optionSensitive: true, optionSplit: true,
+		optionSigned: true, optionEncrypted: true, optionDeprecated: true, optionBase64: true, optionFiles: true,
+	}
+
+	valueOptions = map[string]bool{
+		optionRequiredIf: true, optionRequiredWithout: true, optionSanitize: true, optionDeprecated: true,
+		optionPrefix: true, optionFactory: true, optionDefault: true, optionAllow: true,
+		optionFields: true, optionOps: true, optionMaxSize: true, optionMaxTotal: true, optionMIME: true, optionFunc: true,
+	}
+)
+
+// Register compiles and checks the binding plan of params, a struct or a pointer to struct,
+// so misconfigured tags are reported at init rather than silently ignored on the first request.
+func Register(params interface{}) error {
+	return defaultRegistry.Register(params)
+}
+
+// MustRegister same as Register, but panics on invalid tags.
+//
+//	var _ = easybind.MustRegister(&CreateUserArgs{})
+func MustRegister(params interface{}) bool {
+	if err := Register(params); err != nil {
+		panic(err)
+	}
+
+	return true
+}
+
+// Precompile compiles the binding plans of params structs, or pointers to them, and warms the JSON decoders
+// of those with a body, so the first requests of latency sensitive services don't pay for it.
+// It returns the first error as Register, every type is compiled though.
+func Precompile(types ...interface{}) error {
+	return defaultRegistry.Precompile(types...)
+}
+
+// Precompile same as Precompile, with the converters and sources of r.
+func (r *Registry) Precompile(types ...interface{}) (err error) {
+	for _, params := range types {
+		if regErr := r.Register(params); regErr != nil {
+			if err == nil {
+				err = regErr
+			}
+			continue
+		}
+
+		typ := reflect.TypeOf(params)
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		json.Unmarshal([]byte("{}"), reflect.New(typ).Interface())
+	}
+
+	return
+}
+
+// PrecompileStrict same as Precompile, but also reports the fields never bound, see ErrUnsupportedField,
+// e.g. in a test of every params struct.
+func PrecompileStrict(types ...interface{}) error {
+	return defaultRegistry.PrecompileStrict(types...)
+}
+
+// PrecompileStrict same as PrecompileStrict, with the converters and sources of r.
+func (r *Registry) PrecompileStrict(types ...interface{}) error {
+	err := r.Precompile(types...)
+	for _, params := range types {
+		typ := reflect.TypeOf(params)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if err != nil || typ == nil || typ.Kind() != reflect.Struct {
+			continue
+		}
+
+		if skipped := r.compile(typ).skipped; len(skipped) > 0 {
+			err = skipped[0]
+		}
+	}
+
+	return err
+}
+
+// PlanStats size of the cache of binding plans.
+type PlanStats struct {
+	// Types compiled params struct types, embedded ones included
+	Types int
+	// Fields planned fields of all types
+	Fields int
+	// Bytes approximate memory held by the plans
+	Bytes uintptr
+}
+
+// Plans returns the size of the cache of binding plans, e.g. to report it once Precompile is done.
+func Plans() PlanStats {
+	return defaultRegistry.Plans()
+}
+
+// Plans same as Plans, of the plans compiled by r.
+func (r *Registry) Plans() PlanStats {
+	var stats PlanStats
+	r.plans.Range(func(_, value interface{}) bool {
+		p := value.(*plan)
+		stats.Types++
+		stats.Fields += len(p.fields)
+		stats.Bytes += unsafe.Sizeof(*p) + uintptr(cap(p.fields))*unsafe.Sizeof(fieldPlan{})
+		return true
+	})
+
+	return stats
+}
+
+// compile returns the cached plan of typ, a struct type.
+func (r *Registry) compile(typ reflect.Type) *plan {
+	return r.compilePath(typ, nil)
+}
+
+// compilePath returns the cached plan of typ, embedded or nested in the types of path being compiled.
+func (r *Registry) compilePath(typ reflect.Type, path []reflect.Type) *plan {
+	if p, ok := r.plans.Load(typ); ok {
+		return p.(*plan)
+	}
+
+	path = append(path[:len(path):len(path)], typ)
+	p := &plan{fields: make([]fieldPlan, 0, typ.NumField()), names: make(map[string]promoted)}
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := r.externalField(typ, typ.Field(i))
+
+		var err error
+		embedded, isEmbedded := embeddedStruct(fieldType)
+		switch inner, ok := recursiveType(fieldType); {
+		case ok && inPath(path, inner):
+			err = fmt.Errorf("%w %s.%s: %s", ErrTypeCycle, typ, fieldType.Name, cycle(path, inner))
+		case isEmbedded:
+			inner := r.compilePath(embedded, path)
+			err = inner.err
+			p.skipped = append(p.skipped, inner.skipped...)
+			p.promote(inner.names)
+			p.params = p.params || inner.params
+		default:
+			err = r.checkTag(typ, fieldType, path)
+		}
+
+		if p.err == nil {
+			p.err = err
+		}
+
+		if errors.Is(err, ErrTypeCycle) {
+			continue
+		}
+
+		if reason := unsupported(fieldType); len(reason) > 0 {
+			p.skipped = append(p.skipped, fmt.Errorf("%w %s.%s: %s", ErrUnsupportedField, typ, fieldType.Name, reason))
+			continue
+		}
+
+		f := fieldPlan{index: i, fieldType: fieldType}
+		if pointed, ok := r.pointedStruct(fieldType); ok && !inPath(path, pointed) && r.compilePath(pointed, path).params {
+			// recursive pointed structs, e.g. linked lists, are only decoded from the json body
+			f.pointed = pointed
+			p.params = true
+		}
+
+		p.fields = append(p.fields, f)
+		if !isEmbedded {
+			loc, _ := getInTagLocAndName(fieldType)
+			p.names[fieldType.Name] = promoted{count: 1, bound: loc != inTagBody}
+			p.params = p.params || loc != inTagBody
+		}
+	}
+
+	if err := p.ambiguous(typ); p.err == nil {
+		p.err = err
+	}
+
+	actual, _ := r.plans.LoadOrStore(typ, p)
+	return actual.(*plan)
+}
+
+// promote adds the names of an embedded struct, one level deeper, unless shadowed by shallower ones.
+func (p *plan) promote(names map[string]promoted) {
+	for name, inner := range names {
+		inner.depth++
+		switch outer, ok := p.names[name]; {
+		case !ok || inner.depth < outer.depth:
+			p.names[name] = promoted{depth: inner.depth, count: 1, bound: inner.bound}
+		case inner.depth == outer.depth:
+			outer.count++
+			outer.bound = outer.bound || inner.bound
+			p.names[name] = outer
+		}
+	}
+}
+
+// ambiguous returns an ErrAmbiguousField error for the first name, in order, promoted by several embedded
+// structs at the same depth and bound from parameters, which Go doesn't promote and binding can't tell apart.
+func (p *plan) ambiguous(typ reflect.Type) error {
+	var names []string
+	for name, promoted := range p.names {
+		if promoted.count > 1 && promoted.bound {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+	return fmt.Errorf("%w %s.%s: promoted by %d embedded structs", ErrAmbiguousField, typ, names[0], p.names[names[0]].count)
+}
+
+// recursiveType returns the struct type fieldType embeds, or nests by the prefix option.
+func recursiveType(fieldType reflect.StructField) (reflect.Type, bool) {
+	if embedded, ok := embeddedStruct(fieldType); ok {
+		return embedded, true
+	}
+
+	if _, ok := getInTagOption(fieldType, optionPrefix); !ok {
+		return nil, false
+	}
+
+	typ := fieldType.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ, typ.Kind() == reflect.Struct
+}
+
+func inPath(path []reflect.Type, typ reflect.Type) bool {
+	for _, t := range path {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cycle describes the cycle of path closed by typ, e.g. a.A -> a.B -> a.A.
+func cycle(path []reflect.Type, typ reflect.Type) string {
+	var names []string
+	for i := len(path) - 1; i >= 0; i-- {
+		names = append([]string{path[i].String()}, names...)
+		if path[i] == typ {
+			break
+		}
+	}
+
+	return strings.Join(append(names, typ.String()), " -> ")
+}
+
+// unsupported returns why fieldType is never bound, empty if it may be: unexported fields but embedded structs,
+// funcs, channels and unsafe pointers.
+func unsupported(fieldType reflect.StructField) string {
+	if _, ok := embeddedStruct(fieldType); ok {
+		return ""
+	}
+
+	if len(fieldType.PkgPath) > 0 {
+		return "unexported field"
+	}
+
+	typ := fieldType.Type
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return "unsupported type " + fieldType.Type.String()
+	}
+
+	return ""
+}
+
+// checkTag reports why the pos tag of fieldType can't be bound.
+func (r *Registry) checkTag(typ reflect.Type, fieldType reflect.StructField, path []reflect.Type) error {
+	inTag, ok := fieldType.Tag.Lookup(tagNameIn)
+	if !ok {
+		return nil
+	}
+
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w of %s.%s: %s", ErrInvalidTag, typ, fieldType.Name, fmt.Sprintf(format, args...))
+	}
+
+	if len(fieldType.PkgPath) > 0 {
+		return invalid("unexported field")
+	}
+
+	tag := ParseTag(fieldType)
+	_, nested := tag.Get(optionPrefix)
+	factory, hasFactory := tag.Get(optionFactory)
+	fn, hasFn := tag.Get(optionFunc)
+	for _, option := range tag.Options {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) == 2 && valueOptions[kv[0]] || options[option] {
+			continue
+		}
+
+		if _, ok := r.Transforms[option]; ok {
+			continue
+		}
+
+		return invalid("unknown option %q", option)
+	}
+
+	if names, ok := tag.Get(optionSanitize); ok {
+		for _, name := range strings.Split(names, optionFieldSep) {
+			if _, ok := r.Sanitizers[name]; !ok {
+				return invalid("no %s %q", optionSanitize, name)
+			}
+		}
+	}
+
+	switch {
+	case len(tag.Source) == 0:
+		return invalid("malformed %q, want source:name", inTag)
+	case !sources[tag.Source] && !r.isCustomSource(tag.Source):
+		return invalid("unknown source %q", tag.Source)
+	case hasFactory:
+		val, ok := r.factoryValue(factory, fieldType.Type)
+		switch {
+		case fieldType.Type.Kind() != reflect.Interface:
+			return invalid("%s binds an interface, not %s", optionFactory, fieldType.Type)
+		case !ok:
+			return invalid("no %s %q of %s", optionFactory, factory, fieldType.Type)
+		case tag.Source != inTagBody && !r.hasBinder(val.Type().Elem()):
+			return invalid("no binder for %s", val.Type().Elem())
+		}
+	case tag.Source == inTagBody:
+		return nil
+	case hasFn:
+		if _, ok := r.Funcs[fn]; !ok {
+			return invalid("no %s %q", optionFunc, fn)
+		}
+	case nested:
+		inner := fieldType.Type
+		if inner.Kind() == reflect.Ptr {
+			inner = inner.Elem()
+		}
+
+		switch {
+		case inner.Kind() != reflect.Struct:
+			return invalid("%s binds a struct, not %s", optionPrefix, fieldType.Type)
+		case len(tag.Name) > 0:
+			return invalid("malformed %q, want source,%s=...", inTag, optionPrefix)
+		}
+
+		return r.compilePath(inner, path).err
+	case tag.Source == inTagRequest && !requestNames[tag.Name]:
+		return invalid("unknown request attribute %q", tag.Name)
+	case isFile(fieldType.Type):
+		if tag.Source != inTagForm {
+			return invalid("%s binds from form, not %s", fieldType.Type, tag.Source)
+		}
+		for _, option := range []string{optionMaxSize, optionMaxTotal} {
+			if size, ok := tag.Get(option); ok {
+				if _, err := parseSize(size); err != nil {
+					return invalid("%v", err)
+				}
+			}
+		}
+		if isWildcard(tag.Name) && (fieldType.Type != fileHeadersType || !tag.Has(optionFiles)) {
+			return invalid("%s:%s binds files into %s with the %s option", tag.Source, tag.Name, fileHeadersType, optionFiles)
+		}
+	case fieldType.Type == filterConditionsType:
+		if tag.Source != inTagQuery {
+			return invalid("%s binds from query, not %s", filterConditionsType, tag.Source)
+		}
+		if fields, ok := tag.Get(optionFields); !ok || len(fields) == 0 {
+			return invalid("%s requires the %s option, the fields it may filter", filterConditionsType, optionFields)
+		}
+	case (tag.Source == inTagQuery || tag.Source == inTagForm) && r.isStructSlice(fieldType.Type):
+		elem := fieldType.Type.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if inPath(path, elem) {
+			// rows of the same type, bound as deep as the parameters go
+			return nil
+		}
+		return r.compilePath(elem, path).err
+	case (tag.Source == inTagQuery || tag.Source == inTagForm) && r.isParamMap(fieldType.Type):
+		return nil
+	case fieldType.Type == sparseFieldsType:
+		if tag.Source != inTagQuery {
+			return invalid("%s binds from query, not %s", sparseFieldsType, tag.Source)
+		}
+	case isWildcard(tag.Name):
+		if !fieldType.Type.ConvertibleTo(stringSliceMapType) && !fieldType.Type.ConvertibleTo(stringMapType) {
+			return invalid("%s:%s binds into a map, not %s", tag.Source, tag.Name, fieldType.Type)
+		}
+	case !r.hasBinder(fieldType.Type):
+		return invalid("no binder for %s", fieldType.Type)
+	}
+
+	return nil
+}
+
+// hasBinder reports whether a string can be bound to typ.
+func (r *Registry) hasBinder(typ reflect.Type) bool {
+	if _, ok := r.TypeBinders[typ]; ok {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return r.hasBinder(typ.Elem())
+	}
+
+	_, ok := r.KindBinders[typ.Kind()]
+	return ok
+}
@@ -0,0 +1,193 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	type valid struct {
+		pageArgs
+		ID      string            `pos:"path:id,required"`
+		Since   time.Time         `pos:"query:since"`
+		Tags    []string          `pos:"header:X-Tag,split,trim,lower"`
+		Headers map[string]string `pos:"header:X-*"`
+		IP      string            `pos:"request:client_ip"`
+		Name    string            `json:"name" pos:"body,readonly"`
+		Email   string            `pos:"query:email,required_without=Phone,sanitize=html"`
+		Phone   string            `pos:"query:phone"`
+	}
+
+	assert.Nil(t, Register(&valid{}))
+	assert.True(t, MustRegister(valid{}))
+
+	invalids := []interface{}{
+		&struct {
+			ID string `pos:":id"`
+		}{},
+		&struct {
+			ID string `pos:"session:id"`
+		}{},
+		&struct {
+			ID string `pos:"path:id,requird"`
+		}{},
+		&struct {
+			Page pageArgs `pos:"query:page"`
+		}{},
+		&struct {
+			Tags string `pos:"header:X-*"`
+		}{},
+		&struct {
+			IP string `pos:"request:ip"`
+		}{},
+		&struct {
+			Ch chan int `pos:"query:ch"`
+		}{},
+		&struct {
+			id string `pos:"query:id"`
+		}{},
+		&struct {
+			Bio string `pos:"query:bio,sanitize=control|htlm"`
+		}{},
+	}
+
+	for _, invalid := range invalids {
+		err := Register(invalid)
+		assert.True(t, errors.Is(err, ErrInvalidTag), "%T", invalid)
+		assert.Panics(t, func() { MustRegister(invalid) })
+	}
+
+	assert.EqualError(t, Register(invalids[1]), `invalid pos tag of struct { ID string "pos:\"session:id\"" }.ID: unknown source "session"`)
+	assert.EqualError(t, Register(invalids[len(invalids)-1]), `invalid pos tag of struct { Bio string "pos:\"query:bio,sanitize=control|htlm\"" }.Bio: no sanitize "htlm"`)
+}
+
+func TestPrecompile(t *testing.T) {
+	type embedded struct {
+		Page int `pos:"query:page"`
+	}
+	type precompiled struct {
+		embedded
+		ID   string `pos:"path:id"`
+		Name string `json:"name"`
+	}
+
+	assert.Nil(t, Precompile(&precompiled{}))
+	for _, typ := range []reflect.Type{reflect.TypeOf(precompiled{}), reflect.TypeOf(embedded{})} {
+		_, ok := defaultRegistry.plans.Load(typ)
+		assert.True(t, ok, typ.String())
+	}
+
+	stats := Plans()
+	assert.True(t, stats.Types >= 2)
+	assert.True(t, stats.Fields >= 4)
+	assert.True(t, stats.Bytes > 0)
+
+	err := Precompile(&struct {
+		ID string `pos:":id"`
+	}{}, 1)
+	assert.True(t, errors.Is(err, ErrInvalidTag))
+}
+
+func TestPrecompileStrict(t *testing.T) {
+	type unsupportedArgs struct {
+		ID       string `pos:"query:id"`
+		OnDone   func() `json:"-"`
+		Events   chan int
+		internal string
+	}
+
+	assert.Nil(t, Precompile(&unsupportedArgs{}))
+	err := PrecompileStrict(&unsupportedArgs{})
+	assert.ErrorIs(t, err, ErrUnsupportedField)
+	assert.EqualError(t, err, "unsupported field easybind.unsupportedArgs.OnDone: unsupported type func()")
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?id=1&internal=x", nil)
+	a := unsupportedArgs{internal: "kept"}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, "1", a.ID)
+	assert.Equal(t, "kept", a.internal)
+
+	type strictArgs struct {
+		ID string `pos:"query:id"`
+	}
+	assert.Nil(t, PrecompileStrict(&strictArgs{}))
+}
+
+func TestRegistryPrecompile(t *testing.T) {
+	type registryPrecompiled struct {
+		ID     string `pos:"query:id"`
+		OnDone func() `json:"-"`
+	}
+
+	r := NewRegistry()
+	assert.Nil(t, r.Precompile(&registryPrecompiled{}))
+	_, ok := r.plans.Load(reflect.TypeOf(registryPrecompiled{}))
+	assert.True(t, ok)
+	_, ok = defaultRegistry.plans.Load(reflect.TypeOf(registryPrecompiled{}))
+	assert.False(t, ok)
+	assert.Equal(t, 1, r.Plans().Types)
+	assert.ErrorIs(t, r.PrecompileStrict(&registryPrecompiled{}), ErrUnsupportedField)
+
+	// sources registered in r only
+	r.RegisterSource(namedSource("tenant"))
+	type tenantArgs struct {
+		Tenant string `pos:"tenant:id"`
+	}
+	assert.Nil(t, r.Precompile(&tenantArgs{}))
+	assert.ErrorIs(t, Precompile(&tenantArgs{}), ErrInvalidTag)
+}
+
+type cyclic struct {
+	*cyclic
+	ID string `pos:"query:id"`
+}
+
+type nodeArgs struct {
+	Name  string     `pos:"query:name"`
+	Child *nodeArgs  `pos:"query,prefix=child_"`
+	Rows  []nodeArgs `pos:"query:rows"`
+}
+
+type createdBy struct {
+	ID string `pos:"query:id"`
+}
+
+type updatedBy struct {
+	ID string `pos:"query:id"`
+}
+
+func TestRegisterEmbedding(t *testing.T) {
+	err := Register(&cyclic{})
+	assert.ErrorIs(t, err, ErrTypeCycle)
+	assert.EqualError(t, err, "recursive struct type easybind.cyclic.cyclic: easybind.cyclic -> easybind.cyclic")
+	assert.ErrorIs(t, Register(&nodeArgs{}), ErrTypeCycle)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/nodes?id=1&name=a&child_name=b&rows.0.name=c", nil)
+	c := cyclic{}
+	assert.Nil(t, Bind(req, &c))
+	assert.Equal(t, cyclic{ID: "1"}, c)
+
+	n := nodeArgs{}
+	assert.Nil(t, Bind(req, &n))
+	assert.Equal(t, nodeArgs{Name: "a", Rows: []nodeArgs{{Name: "c"}}}, n)
+
+	type ambiguousArgs struct {
+		createdBy
+		updatedBy
+	}
+	err = Register(&ambiguousArgs{})
+	assert.ErrorIs(t, err, ErrAmbiguousField)
+	assert.EqualError(t, err, "ambiguous field easybind.ambiguousArgs.ID: promoted by 2 embedded structs")
+
+	type shadowedArgs struct {
+		createdBy
+		updatedBy
+		ID string `pos:"query:id"`
+	}
+	assert.Nil(t, Register(&shadowedArgs{}))
+}
@@ -0,0 +1,67 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// isBodyPointer reports whether name, of a field tagged `pos:"body:/data/attributes/name"`,
+// is a JSON Pointer into the json body.
+func isBodyPointer(name string) bool {
+	return strings.HasPrefix(name, "/")
+}
+
+// bodyPointer a field receiving the value a JSON Pointer references in the json body.
+type bodyPointer struct {
+	name    string
+	pointer string
+	field   reflect.Value
+}
+
+func (e *easyReq) addBodyPointer(field reflect.Value, fieldType reflect.StructField, pointer string) {
+	e.mu.Lock()
+	e.hasJSONBody = true
+	e.bodyPointers = append(e.bodyPointers, bodyPointer{name: fieldType.Name, pointer: pointer, field: field})
+	e.mu.Unlock()
+}
+
+// decodeBodyPointers decodes the values referenced in data, the json body, into every field tagged
+// with a JSON Pointer; fields whose value is missing are left as is.
+func (e *easyReq) decodeBodyPointers(data []byte) error {
+	if len(e.bodyPointers) == 0 {
+		return nil
+	}
+
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return err
+	}
+
+	api := e.binder.Discriminators.jsonAPI()
+	for _, bp := range e.bodyPointers {
+		p, err := parsePointer(bp.pointer)
+		if err != nil {
+			return &BindError{Field: bp.name, Source: inTagBody, Name: bp.pointer, Err: err}
+		}
+
+		value, err := p.get(doc)
+		if err != nil {
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		v := reflect.New(bp.field.Type())
+		if err = api.Unmarshal(raw, v.Interface()); err != nil {
+			return &BindError{Field: bp.name, Source: inTagBody, Name: bp.pointer, Err: err}
+		}
+
+		bp.field.Set(v.Elem())
+		e.setField(bp.name, value != nil)
+	}
+
+	return nil
+}
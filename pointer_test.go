@@ -0,0 +1,38 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindBodyPointer(t *testing.T) {
+	type createArgs struct {
+		Name  string   `json:"-" pos:"body:/data/attributes/name,required"`
+		Tags  []string `json:"-" pos:"body:/data/attributes/tags"`
+		First int      `json:"-" pos:"body:/data/items/0/n"`
+		Slash string   `json:"-" pos:"body:/meta/a~1b"`
+		Kind  string   `json:"kind"`
+	}
+
+	body := `{"kind": "user", "data": {"attributes": {"name": "bob", "tags": ["a"]}, "items": [{"n": 7}]}, "meta": {"a/b": "x"}}`
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(body))
+	a := createArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, createArgs{Name: "bob", Tags: []string{"a"}, First: 7, Slash: "x", Kind: "user"}, a)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(`{"data": {}}`))
+	err := Bind(req, &createArgs{})
+	var be *BindError
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, "/data/attributes/name", be.Name)
+	assert.True(t, errors.Is(err, ErrRequired))
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users", strings.NewReader(`{"data": {"attributes": {"name": 1}}}`))
+	err = Bind(req, &createArgs{})
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, "Name", be.Field)
+}
@@ -0,0 +1,59 @@
+package easybind
+
+import (
+	"context"
+	"net/http"
+)
+
+// BodyPolicy how the binder treats the body of a request.
+type BodyPolicy int
+
+const (
+	// BodyAllowed the body is decoded, the default
+	BodyAllowed BodyPolicy = iota
+	// BodyIgnored the body is never read, e.g. for GET, HEAD and DELETE requests
+	BodyIgnored
+	// BodyRejected a request with a body fails with ErrUnexpectedBody
+	BodyRejected
+)
+
+type bodyPolicyKey struct{}
+
+// WithBodyPolicy returns a copy of ctx selecting policy when binding, overriding Binder.BodyPolicies for a route.
+func WithBodyPolicy(ctx context.Context, policy BodyPolicy) context.Context {
+	return context.WithValue(ctx, bodyPolicyKey{}, policy)
+}
+
+// bodyPolicy selected by the request's context, or by b.BodyPolicies for its method.
+func (b *Binder) bodyPolicy(req *http.Request) BodyPolicy {
+	if policy, ok := req.Context().Value(bodyPolicyKey{}).(BodyPolicy); ok {
+		return policy
+	}
+
+	return b.BodyPolicies[req.Method]
+}
+
+// checkBody returns ErrUnexpectedBody if the policy rejects the body req has, or why its body
+// doesn't verify, and whether the body may be read.
+func (b *Binder) checkBody(req *http.Request) (read bool, err error) {
+	switch b.bodyPolicy(req) {
+	case BodyIgnored:
+		return false, nil
+	case BodyRejected:
+		if hasBody(req) {
+			return false, ErrUnexpectedBody
+		}
+		return false, nil
+	}
+
+	if err = b.verifyBody(req); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// hasBody reports whether req has a body, chunked ones included.
+func hasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
@@ -0,0 +1,50 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindBodyPolicy(t *testing.T) {
+	b := *DefaultBinder
+	b.BodyPolicies = map[string]BodyPolicy{http.MethodGet: BodyIgnored, http.MethodDelete: BodyRejected}
+
+	body := `{"age": 3}`
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?ids=1", strings.NewReader(body))
+	a := queryUsersArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Len(t, a.IDs, 1)
+	assert.Zero(t, a.Age)
+
+	req, _ = http.NewRequest(http.MethodDelete, "https://hello.world/users?ids=1", strings.NewReader(body))
+	assert.Equal(t, ErrUnexpectedBody, b.Bind(req, &queryUsersArgs{}))
+
+	req, _ = http.NewRequest(http.MethodDelete, "https://hello.world/users?ids=1", nil)
+	assert.Nil(t, b.Bind(req, &queryUsersArgs{}))
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users?ids=1", strings.NewReader(body))
+	a = queryUsersArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, 3, a.Age)
+
+	req = req.WithContext(WithBodyPolicy(req.Context(), BodyRejected))
+	assert.Equal(t, ErrUnexpectedBody, b.Bind(req, &queryUsersArgs{}))
+
+	type formArgs struct {
+		Name string `pos:"form:name"`
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users", strings.NewReader("name=bob"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	f := formArgs{}
+	assert.Nil(t, b.Bind(req, &f))
+	assert.Empty(t, f.Name)
+
+	m := map[string]interface{}{}
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users", strings.NewReader(body))
+	assert.Nil(t, b.Bind(req, &m))
+	assert.Empty(t, m)
+}
@@ -0,0 +1,15 @@
+// Command postag checks easybind pos struct tags, run it standalone or by go vet:
+//
+//	go install github.com/momaek/easybind/postag/cmd/postag@latest
+//	go vet -vettool=$(which postag) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/momaek/easybind/postag"
+)
+
+func main() {
+	singlechecker.Main(postag.Analyzer)
+}
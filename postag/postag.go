@@ -0,0 +1,292 @@
+// Package postag defines an analyzer reporting easybind `pos` tags which would misbehave at runtime:
+// malformed tags, unknown sources and options, and fields whose type can't be bound from their source.
+package postag
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer checks easybind `pos` tags.
+var Analyzer = &analysis.Analyzer{
+	Name:     "postag",
+	Doc:      "check easybind pos struct tags",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&customSources, "sources", "", "comma separated names of the sources registered by easybind.RegisterSource")
+	Analyzer.Flags.StringVar(&customOptions, "options", "", "comma separated names of the transforms added to easybind.Transforms")
+}
+
+var (
+	// customSources value of the -sources flag
+	customSources string
+	// customOptions value of the -options flag
+	customOptions string
+
+	sources = map[string]bool{
+		"path": true, "query": true, "header": true, "form": true, "cookie": true, "request": true, "body": true,
+		"flag": true, "env": true, "matrix": true,
+	}
+
+	requestNames = map[string]bool{
+		"client_ip": true, "remote_addr": true, "method": true, "host": true, "path": true,
+	}
+
+	options = map[string]bool{
+		"required": true, "readonly": true, "sensitive": true, "split": true, "signed": true, "encrypted": true,
+		"deprecated": true, "trim": true, "lower": true, "upper": true, "squash": true, "base64": true,
+		"files": true,
+	}
+
+	valueOptions = map[string]bool{
+		"required_if": true, "required_without": true, "sanitize": true, "deprecated": true,
+		"prefix": true, "factory": true, "default": true, "allow": true,
+		"fields": true, "ops": true, "maxsize": true, "maxtotal": true, "mime": true, "func": true,
+	}
+)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+
+			inTag, ok := reflect.StructTag(tag).Lookup("pos")
+			if !ok {
+				continue
+			}
+
+			checkTag(pass, field, inTag)
+		}
+	})
+
+	return nil, nil
+}
+
+func checkTag(pass *analysis.Pass, field *ast.Field, inTag string) {
+	splits := strings.Split(inTag, ",")
+	for _, option := range splits[1:] {
+		option = strings.TrimSpace(option)
+		if kv := strings.SplitN(option, "=", 2); len(kv) == 2 && valueOptions[kv[0]] || options[option] || listed(customOptions, option) {
+			continue
+		}
+
+		pass.Reportf(field.Tag.Pos(), "unknown pos tag option %q", option)
+	}
+
+	if splits[0] == "" || strings.TrimSpace(strings.SplitN(splits[0], ":", 2)[0]) == "body" {
+		// decoded as json, JSON Pointers included
+		return
+	}
+
+	if strings.Contains(","+strings.ReplaceAll(inTag, " ", ""), ",prefix=") {
+		checkNested(pass, field, inTag, splits[0])
+		return
+	}
+
+	locs := strings.Split(splits[0], ":")
+	if len(locs) == 1 && len(field.Names) > 0 {
+		// no name, derived from the field name
+		locs = append(locs, field.Names[0].Name)
+	}
+	if len(locs) != 2 || len(locs[0]) == 0 || len(locs[1]) == 0 {
+		pass.Reportf(field.Tag.Pos(), "malformed pos tag %q, want source:name", inTag)
+		return
+	}
+
+	loc, name := locs[0], locs[1]
+	if !sources[loc] && !listed(customSources, loc) {
+		pass.Reportf(field.Tag.Pos(), "unknown pos tag source %q", loc)
+		return
+	}
+
+	if loc == "request" && !requestNames[name] {
+		pass.Reportf(field.Tag.Pos(), "unknown request attribute %q", name)
+	}
+
+	typ := pass.TypesInfo.TypeOf(field.Type)
+	if typ == nil {
+		return
+	}
+
+	if strings.Contains(","+strings.ReplaceAll(inTag, " ", ""), ",factory=") {
+		// bound as the concrete value of the registered factory
+		if !types.IsInterface(typ) {
+			pass.Reportf(field.Tag.Pos(), "factory binds an interface field, not %s", typ)
+		}
+		return
+	}
+
+	if strings.Contains(","+strings.ReplaceAll(inTag, " ", ""), ",func=") {
+		// parsed by the registered func, whatever the field type
+		return
+	}
+
+	if strings.HasSuffix(name, "*") && loc == "form" && isFileHeaders(typ) {
+		if !strings.Contains(","+strings.ReplaceAll(inTag, " ", "")+",", ",files,") {
+			pass.Reportf(field.Tag.Pos(), "%s:%s binds files into %s with the files option", loc, name, typ)
+		}
+		return
+	}
+
+	if strings.HasSuffix(name, "*") {
+		if _, ok := typ.Underlying().(*types.Map); !ok {
+			pass.Reportf(field.Tag.Pos(), "%s:%s binds into a map field, not %s", loc, name, typ)
+		}
+		return
+	}
+
+	if (loc == "query" || loc == "form") && isStructSlice(typ) {
+		return
+	}
+
+	if m, ok := typ.Underlying().(*types.Map); ok && (loc == "query" || loc == "form") {
+		// parameters grouped by name, e.g. attr.color
+		if reason := unbindable(m.Elem()); len(reason) > 0 {
+			pass.Reportf(field.Tag.Pos(), "%s can't be bound from %s: %s", typ, loc, reason)
+		}
+		return
+	}
+
+	if reason := unbindable(typ); len(reason) > 0 {
+		pass.Reportf(field.Tag.Pos(), "%s can't be bound from %s: %s", typ, loc, reason)
+	}
+}
+
+// checkNested checks the tag of a nested struct field, e.g. `pos:"query,prefix=addr_"`.
+func checkNested(pass *analysis.Pass, field *ast.Field, inTag, loc string) {
+	if strings.Contains(loc, ":") {
+		pass.Reportf(field.Tag.Pos(), "malformed pos tag %q, want source,prefix=...", inTag)
+		return
+	}
+
+	if !sources[loc] && !listed(customSources, loc) {
+		pass.Reportf(field.Tag.Pos(), "unknown pos tag source %q", loc)
+		return
+	}
+
+	typ := pass.TypesInfo.TypeOf(field.Type)
+	if typ == nil {
+		return
+	}
+
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	if _, ok := typ.Underlying().(*types.Struct); !ok {
+		pass.Reportf(field.Tag.Pos(), "prefix binds a struct field, not %s", typ)
+	}
+}
+
+// isStructSlice reports whether typ is a slice of structs, or of pointers to structs, bound from indexed parameters.
+func isStructSlice(typ types.Type) bool {
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok || isBuiltin(slice.Elem()) {
+		return false
+	}
+
+	elem := slice.Elem()
+	if ptr, ok := elem.Underlying().(*types.Pointer); ok {
+		elem = ptr.Elem()
+	}
+
+	_, ok = elem.Underlying().(*types.Struct)
+	return ok && !isBuiltin(elem)
+}
+
+// listed reports whether name is one of the comma separated names of list, the value of a flag.
+func listed(list, name string) bool {
+	for _, n := range strings.Split(list, ",") {
+		if strings.TrimSpace(n) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unbindable returns why a value of typ can't be converted from a string, empty if it can.
+func unbindable(typ types.Type) string {
+	if isBuiltin(typ) {
+		return ""
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		return unbindable(t.Elem())
+	case *types.Slice:
+		return unbindable(t.Elem())
+	case *types.Array:
+		return unbindable(t.Elem())
+	case *types.Basic:
+		if t.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0 {
+			return ""
+		}
+		return "unsupported basic type"
+	case *types.Struct:
+		return "nested struct, only supported in body"
+	case *types.Map:
+		return "map, only supported with a wildcard name, or grouped query and form parameters"
+	case *types.Chan, *types.Signature:
+		return "unsupported type"
+	case *types.Interface:
+		return "interface"
+	}
+
+	return ""
+}
+
+// isFileHeaders reports whether typ is []*multipart.FileHeader, bound from every file of a form with the files option.
+func isFileHeaders(typ types.Type) bool {
+	slice, ok := typ.(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	ptr, ok := slice.Elem().(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "mime/multipart" && named.Obj().Name() == "FileHeader"
+}
+
+// isBuiltin reports whether typ has a binder registered by easybind itself, e.g. time.Time or easybind.Range.
+func isBuiltin(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	switch named.Obj().Pkg().Path() {
+	case "time":
+		return named.Obj().Name() == "Time"
+	case "net":
+		return named.Obj().Name() == "IP"
+	case "mime/multipart":
+		return named.Obj().Name() == "FileHeader"
+	case "github.com/momaek/easybind":
+		return true
+	}
+
+	return false
+}
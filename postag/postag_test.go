@@ -0,0 +1,13 @@
+package postag
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	Analyzer.Flags.Set("sources", "tenant")
+	Analyzer.Flags.Set("options", "slug")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
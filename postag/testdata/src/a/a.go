@@ -0,0 +1,40 @@
+package a
+
+import (
+	"fmt"
+	"mime/multipart"
+	"time"
+)
+
+type inner struct {
+	Name string
+}
+
+type args struct {
+	ID      string                 `pos:"path:id"`
+	Since   time.Time              `pos:"query:since,required"`
+	Tags    []string               `pos:"header:X-Tag,split,trim"`
+	Headers map[string]string      `pos:"header:X-*"`
+	Body    inner                  `json:"body" pos:"body,readonly"`
+	Email   string                 `pos:"query:email,required_without=Phone"`
+	Tenant  string                 `pos:"tenant:id"`
+	Data    inner                  `pos:"body:/data/attributes"`
+	Meta    map[string]interface{} `pos:"body:/meta"`
+
+	Bad     string                  `pos:":bad"`            // want `malformed pos tag ":bad", want source:name`
+	Where   string                  `pos:"session:id"`      // want `unknown pos tag source "session"`
+	Option  string                  `pos:"query:o,requird"` // want `unknown pos tag option "requird"`
+	Nested  inner                   `pos:"query:nested"`    // want `a.inner can't be bound from query: nested struct, only supported in body`
+	Funcs   []func()                `pos:"query:funcs"`     // want `\[\]func\(\) can't be bound from query: unsupported type`
+	Wild    string                  `pos:"header:X-*"`      // want `header:X-\* binds into a map field, not string`
+	Request string                  `pos:"request:user"`    // want `unknown request attribute "user"`
+	Map     map[string]string       `pos:"header:m"`        // want `map\[string\]string can't be bound from header: map, only supported with a wildcard name, or grouped query and form parameters`
+	Attrs   map[string][]string     `pos:"query:attr"`
+	Slug    string                  `pos:"query:slug,trim,slug"`
+	Addr    *inner                  `pos:"query,prefix=addr_"`
+	Shape   fmt.Stringer            `pos:"query:shape,factory=circle"`
+	Rows    []inner                 `pos:"form:rows"`
+	Prefix  string                  `pos:"query,prefix=p_"` // want `prefix binds a struct field, not string`
+	Files   []*multipart.FileHeader `pos:"form:*,files"`
+	Uploads []*multipart.FileHeader `pos:"form:*"` // want `form:\* binds files into \[\]\*mime/multipart.FileHeader with the files option`
+}
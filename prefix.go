@@ -0,0 +1,98 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// optionPrefix binds the fields of a nested struct from the parameters with this prefix,
+// e.g. `pos:"query,prefix=addr_"` binds its field `pos:"query:city"` from addr_city.
+const optionPrefix = "prefix"
+
+// nesting the fields of a nested struct tagged with the prefix option.
+type nesting struct {
+	// field name of the nested struct field, dotted if nested deeper
+	field string
+	// source of the fields
+	source string
+	prefix string
+	// suffix of the names, e.g. ] of items[0][sku]
+	suffix string
+}
+
+// apply returns fieldType, a field of the nested struct, tagged as bound from the parameter of the source
+// with the prefix, and named after the nested field, e.g. Address.City. Fields without pos tag are bound
+// by their json name; ok is false for those without one.
+func (n *nesting) apply(fieldType reflect.StructField) (_ reflect.StructField, ok bool) {
+	if len(n.source) == 0 {
+		// pointed struct, its fields keep their tags and those without aren't bound
+		_, tagged := fieldType.Tag.Lookup(tagNameIn)
+		fieldType.Name = n.field + "." + fieldType.Name
+		return fieldType, tagged
+	}
+
+	var pos string
+	if inTag, tagged := fieldType.Tag.Lookup(tagNameIn); tagged {
+		splits := strings.Split(inTag, tagSep)
+		// the nested struct is bound from its source, whatever the source of its fields
+		switch locs := strings.Split(splits[0], ":"); {
+		case len(locs) == 2 && locs[0] != inTagBody:
+			splits[0] = n.source + ":" + n.prefix + locs[1] + n.suffix
+		case len(locs) == 1 && len(locs[0]) > 0 && locs[0] != inTagBody:
+			splits[0] = n.source
+			if _, ok := unnamed(fieldType); ok {
+				splits[0] += ":" + n.prefix + fieldName(fieldType) + n.suffix
+			}
+		}
+
+		for i, s := range splits[1:] {
+			if s = strings.TrimSpace(s); strings.HasPrefix(s, optionPrefix+"=") {
+				splits[i+1] = optionPrefix + "=" + n.prefix + strings.TrimPrefix(s, optionPrefix+"=")
+			}
+		}
+		pos = strings.Join(splits, tagSep)
+	} else {
+		name := jsonName(fieldType)
+		if len(name) == 0 {
+			return fieldType, false
+		}
+		pos = n.source + ":" + n.prefix + name + n.suffix
+	}
+
+	// Lookup returns the first match, so the prepended tag wins.
+	fieldType.Tag = reflect.StructTag(tagNameIn+":"+strconv.Quote(pos)+" ") + fieldType.Tag
+	fieldType.Name = n.field + "." + fieldType.Name
+	return fieldType, true
+}
+
+// nestedStruct returns the nesting of fieldType if it's tagged with the prefix option.
+func nestedStruct(fieldType reflect.StructField) (*nesting, bool) {
+	prefix, ok := getInTagOption(fieldType, optionPrefix)
+	if !ok {
+		return nil, false
+	}
+
+	loc, _ := getInTagLocAndName(fieldType)
+	return &nesting{field: fieldType.Name, source: loc, prefix: prefix}, true
+}
+
+// bindNested binds the fields of field, a struct or a pointer to struct tagged with the prefix option.
+func (e *easyReq) bindNested(field reflect.Value, nest *nesting, errCh chan error) {
+	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+		if field.IsNil() {
+			e.allocPointer(field)
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.Struct {
+		errCh <- errors.New("can't bind to nonstruct value")
+		return
+	}
+
+	if err := e.bindStruct(field, nest); err != nil {
+		errCh <- err
+	}
+}
@@ -0,0 +1,53 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type geo struct {
+	Lat float64 `pos:"query:lat"`
+	Lng float64 `pos:"query:lng"`
+}
+
+type address struct {
+	City string `pos:"query:city,required"`
+	Zip  string `json:"zip"`
+	Geo  *geo   `pos:"query,prefix=geo_"`
+}
+
+type searchArgs struct {
+	Name    string   `pos:"query:name"`
+	Home    address  `pos:"query,prefix=home_"`
+	Work    *address `pos:"query,prefix=work_"`
+	Billing *address `pos:"query,prefix=bill_" json:"-"`
+}
+
+func TestBindPrefix(t *testing.T) {
+	assert.Nil(t, Register(&searchArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/search?name=a&home_city=paris&home_zip=75001&home_geo_lat=48.8&work_city=lyon&bill_city=nice", nil)
+	a := searchArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, searchArgs{
+		Name:    "a",
+		Home:    address{City: "paris", Zip: "75001", Geo: &geo{Lat: 48.8}},
+		Work:    &address{City: "lyon"},
+		Billing: &address{City: "nice"},
+	}, a)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/search?home_city=paris", nil)
+	err := Bind(req, &searchArgs{})
+	var be *BindError
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, "Work.City", be.Field)
+	assert.Equal(t, "work_city", be.Name)
+
+	type badArgs struct {
+		Home string `pos:"query,prefix=home_"`
+	}
+	assert.ErrorIs(t, Register(&badArgs{}), ErrInvalidTag)
+}
@@ -0,0 +1,43 @@
+package easybind
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+type profileKey struct{}
+
+// WithProfile returns a copy of ctx selecting profile when binding, a field tagged `pos.<profile>`
+// is bound by that tag instead of `pos`, e.g. `pos:"query:name" pos.v2:"query:user_name"`.
+func WithProfile(ctx context.Context, profile string) context.Context {
+	return context.WithValue(ctx, profileKey{}, profile)
+}
+
+// profile selected by the request's context, or by b.ProfileHeader.
+func (b *Binder) profile(req *http.Request) string {
+	if profile, ok := req.Context().Value(profileKey{}).(string); ok {
+		return profile
+	}
+
+	if len(b.ProfileHeader) > 0 {
+		return req.Header.Get(b.ProfileHeader)
+	}
+
+	return ""
+}
+
+// profiled returns fieldType with its pos tag replaced by the `pos.<profile>` one if it has any.
+func profiled(fieldType reflect.StructField, profile string) reflect.StructField {
+	if len(profile) == 0 {
+		return fieldType
+	}
+
+	if inTag, ok := fieldType.Tag.Lookup(tagNameIn + "." + profile); ok {
+		// Lookup returns the first match, so the prepended tag wins.
+		fieldType.Tag = reflect.StructTag(tagNameIn+":"+strconv.Quote(inTag)+" ") + fieldType.Tag
+	}
+
+	return fieldType
+}
@@ -0,0 +1,42 @@
+package easybind
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// query returns the query parameters of req, parsed by b.ParseQuery or separated by b.QuerySeparators.
+func (b *Binder) query(req *http.Request) (url.Values, error) {
+	switch {
+	case b.ParseQuery != nil:
+		return b.ParseQuery(req.URL.RawQuery)
+	case len(b.QuerySeparators) > 0:
+		return splitQuery(req.URL.RawQuery, b.QuerySeparators), nil
+	}
+
+	return req.URL.Query(), nil
+}
+
+// splitQuery parses query, its parameters separated by any of seps, skipping malformed ones as url.Query.
+func splitQuery(query, seps string) url.Values {
+	values := url.Values{}
+	for _, param := range strings.FieldsFunc(query, func(r rune) bool { return strings.ContainsRune(seps, r) }) {
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			continue
+		}
+
+		var value string
+		if len(kv) == 2 {
+			if value, err = url.QueryUnescape(kv[1]); err != nil {
+				continue
+			}
+		}
+
+		values[key] = append(values[key], value)
+	}
+
+	return values
+}
@@ -0,0 +1,48 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type legacyQueryArgs struct {
+	Q    string   `pos:"query:q"`
+	Tags []string `pos:"query:tag"`
+	Page int      `pos:"query:page"`
+}
+
+func TestBindQuerySeparators(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/search?q=go%20lang;tag=a&tag=b;page=2", nil)
+
+	a := legacyQueryArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, legacyQueryArgs{}, a)
+
+	a = legacyQueryArgs{}
+	assert.Nil(t, (&Binder{QuerySeparators: "&;"}).Bind(req, &a))
+	assert.Equal(t, legacyQueryArgs{Q: "go lang", Tags: []string{"a", "b"}, Page: 2}, a)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/search?q:go|page:3", nil)
+	b := &Binder{ParseQuery: func(rawQuery string) (url.Values, error) {
+		values := url.Values{}
+		for _, param := range strings.Split(rawQuery, "|") {
+			kv := strings.SplitN(param, ":", 2)
+			if len(kv) != 2 {
+				return nil, errors.New("malformed query")
+			}
+			values.Add(kv[0], kv[1])
+		}
+		return values, nil
+	}}
+	a = legacyQueryArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, legacyQueryArgs{Q: "go", Page: 3}, a)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/search?q", nil)
+	assert.NotNil(t, b.Bind(req, &legacyQueryArgs{}))
+}
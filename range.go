@@ -0,0 +1,102 @@
+package easybind
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RangeSpec one range of a Range header, in units, both ends inclusive.
+// Start -1 is a suffix range of the last End units, End -1 reaches the end.
+type RangeSpec struct {
+	Start int64
+	End   int64
+}
+
+// Range header, e.g. `Range: bytes=0-1023, -500`, bind with `pos:"header:Range"`.
+// A missing or malformed header binds the zero value.
+type Range struct {
+	// Unit usually bytes
+	Unit   string
+	Ranges []RangeSpec
+}
+
+// Resolve returns the ranges as absolute [start, end] offsets in a representation of size units,
+// dropping unsatisfiable ones.
+func (r Range) Resolve(size int64) []RangeSpec {
+	resolved := make([]RangeSpec, 0, len(r.Ranges))
+	for _, spec := range r.Ranges {
+		switch {
+		case spec.Start < 0:
+			if spec.End <= 0 || size == 0 {
+				continue
+			}
+			if spec.End > size {
+				spec.End = size
+			}
+			spec.Start, spec.End = size-spec.End, size-1
+		case spec.Start >= size:
+			continue
+		case spec.End < 0 || spec.End >= size:
+			spec.End = size - 1
+		}
+
+		resolved = append(resolved, spec)
+	}
+
+	return resolved
+}
+
+func parseRange(val string) (r Range, ok bool) {
+	parts := strings.SplitN(strings.TrimSpace(val), "=", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return
+	}
+
+	r.Unit = strings.TrimSpace(parts[0])
+	for _, s := range strings.Split(parts[1], ",") {
+		s = strings.TrimSpace(s)
+		if len(s) == 0 {
+			continue
+		}
+
+		bounds := strings.SplitN(s, "-", 2)
+		if len(bounds) != 2 {
+			return Range{}, false
+		}
+
+		var (
+			spec = RangeSpec{Start: -1, End: -1}
+			err  error
+		)
+
+		if len(bounds[0]) > 0 {
+			if spec.Start, err = strconv.ParseInt(bounds[0], 10, 64); err != nil || spec.Start < 0 {
+				return Range{}, false
+			}
+		}
+
+		if len(bounds[1]) > 0 {
+			if spec.End, err = strconv.ParseInt(bounds[1], 10, 64); err != nil || spec.End < 0 {
+				return Range{}, false
+			}
+		}
+
+		if spec.Start < 0 && spec.End < 0 || spec.Start >= 0 && spec.End >= 0 && spec.End < spec.Start {
+			return Range{}, false
+		}
+
+		r.Ranges = append(r.Ranges, spec)
+	}
+
+	return r, len(r.Ranges) > 0
+}
+
+func rangeBinder(val string, typ reflect.Type) reflect.Value {
+	r, ok := parseRange(val)
+	if !ok {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(r)
+}
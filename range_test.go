@@ -0,0 +1,37 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindRange(t *testing.T) {
+	type args struct {
+		Range Range `pos:"header:Range"`
+	}
+
+	cases := []struct {
+		header   string
+		expected Range
+	}{
+		{"bytes=0-1023", Range{Unit: "bytes", Ranges: []RangeSpec{{0, 1023}}}},
+		{"bytes=0-99, 200-, -50", Range{Unit: "bytes", Ranges: []RangeSpec{{0, 99}, {200, -1}, {-1, 50}}}},
+		{"bytes=10-1", Range{}},
+		{"bytes=-", Range{}},
+		{"0-10", Range{}},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "https://hello.world/files/1", nil)
+		req.Header.Set("Range", c.header)
+
+		a := args{}
+		assert.Nil(t, Bind(req, &a))
+		assert.Equal(t, c.expected, a.Range, c.header)
+	}
+
+	r := Range{Unit: "bytes", Ranges: []RangeSpec{{0, 99}, {200, -1}, {-1, 50}, {1000, 1001}}}
+	assert.Equal(t, []RangeSpec{{0, 99}, {200, 299}, {250, 299}}, r.Resolve(300))
+}
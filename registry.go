@@ -0,0 +1,177 @@
+package easybind
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Registry the converters, sources, transforms, sanitizers, factories, funcs and external tags of a Binder,
+// and the binding plans compiled with them, so libraries embedding easybind don't clobber each other's:
+//
+//	b := &easybind.Binder{Registry: easybind.NewRegistry()}
+//	b.Registry.TypeBinders[reflect.TypeOf(Money{})] = parseMoney
+//
+// Binders without Registry share the package one, TypeBinders, KindBinders, Transforms, Sanitizers, Factories,
+// Funcs, RegisterSource and RegisterTags. Configure a Registry at init, before binding with it.
+type Registry struct {
+	// TypeBinders bind type
+	TypeBinders map[reflect.Type]binder
+	// KindBinders bind kind
+	KindBinders map[reflect.Kind]binder
+	// Transforms see the package Transforms
+	Transforms map[string]func(string) string
+	// Sanitizers see the package Sanitizers
+	Sanitizers map[string]func(string) string
+	// Factories see the package Factories
+	Factories map[string]func() interface{}
+	// Funcs see the package Funcs
+	Funcs map[string]func(value string) (interface{}, error)
+
+	sourcesMu    sync.RWMutex
+	sources      map[string]Source
+	externalTags sync.Map // reflect.Type -> map[string]string
+	plans        sync.Map // reflect.Type -> *plan
+}
+
+// defaultRegistry the package registry, of Binders without their own.
+var defaultRegistry = NewRegistry()
+
+// NewRegistry returns a registry of the builtin converters, transforms and sanitizers only,
+// whatever is registered in the package one.
+func NewRegistry() *Registry {
+	r := &Registry{
+		TypeBinders: map[reflect.Type]binder{
+			reflect.TypeOf(time.Time{}):          timeBinder,
+			reflect.TypeOf(ETags{}):              etagsBinder,
+			reflect.TypeOf(Range{}):              rangeBinder,
+			reflect.TypeOf(net.IP{}):             ipBinder,
+			reflect.TypeOf(TraceParent{}):        traceParentBinder,
+			reflect.TypeOf(TraceState{}):         traceStateBinder,
+			reflect.TypeOf(UserAgent{}):          userAgentBinder,
+			reflect.TypeOf(stdjson.RawMessage{}): rawMessageBinder,
+		},
+		KindBinders: map[reflect.Kind]binder{
+			reflect.Int: intBinder, reflect.Int8: intBinder, reflect.Int16: intBinder,
+			reflect.Int32: intBinder, reflect.Int64: intBinder,
+			reflect.Uint: uintBinder, reflect.Uint8: uintBinder, reflect.Uint16: uintBinder,
+			reflect.Uint32: uintBinder, reflect.Uint64: uintBinder,
+			reflect.Float32: floatBinder, reflect.Float64: floatBinder,
+			reflect.String: stringBinder,
+			reflect.Bool:   boolBinder,
+		},
+		Transforms: map[string]func(string) string{
+			"trim":   strings.TrimSpace,
+			"lower":  strings.ToLower,
+			"upper":  strings.ToUpper,
+			"squash": squashSpaces,
+		},
+		Sanitizers: map[string]func(string) string{
+			"html":    html.EscapeString,
+			"control": StripControl,
+		},
+		Factories: map[string]func() interface{}{},
+		Funcs:     map[string]func(string) (interface{}, error){},
+		sources:   map[string]Source{},
+	}
+	r.KindBinders[reflect.Ptr] = r.pointerBinder
+
+	return r
+}
+
+// registry returns the registry of b, the package one if b has none.
+func (b *Binder) registry() *Registry {
+	if b.Registry != nil {
+		return b.Registry
+	}
+
+	return defaultRegistry
+}
+
+// RegisterSource same as RegisterSource, in r.
+func (r *Registry) RegisterSource(src Source) {
+	name := src.Name()
+	if len(name) == 0 || sources[name] {
+		panic(fmt.Sprintf("easybind: can't register source %q", name))
+	}
+
+	r.sourcesMu.Lock()
+	r.sources[name] = src
+	r.sourcesMu.Unlock()
+}
+
+// lookupSource returns the custom source named name.
+func (r *Registry) lookupSource(name string) (Source, bool) {
+	r.sourcesMu.RLock()
+	src, ok := r.sources[name]
+	r.sourcesMu.RUnlock()
+	return src, ok
+}
+
+func (r *Registry) isCustomSource(name string) bool {
+	_, ok := r.lookupSource(name)
+	return ok
+}
+
+// Register same as Register, with the converters and sources of r.
+func (r *Registry) Register(params interface{}) error {
+	typ := reflect.TypeOf(params)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return errors.New("can't bind to nonstruct value")
+	}
+
+	return r.compile(typ).err
+}
+
+// typeBinder returns the binder registered for typ itself.
+func (r *Registry) typeBinder(typ reflect.Type) (binder, bool) {
+	b, ok := r.TypeBinders[typ]
+	return b, ok
+}
+
+// bindValue binds val to typ, as BindValue, by the binders of r.
+func (r *Registry) bindValue(val string, typ reflect.Type) reflect.Value {
+	if b, ok := r.typeBinder(typ); ok {
+		return b(val, typ)
+	}
+
+	b, ok := r.KindBinders[typ.Kind()]
+	if !ok {
+		return reflect.Zero(typ)
+	}
+
+	return b(val, typ)
+}
+
+// pointerBinder binds val to the value pointed by typ, a pointer type, by the binders of r.
+func (r *Registry) pointerBinder(val string, typ reflect.Type) reflect.Value {
+	if len(val) == 0 {
+		return reflect.Zero(typ)
+	}
+
+	v := r.bindValue(val, typ.Elem())
+	p := reflect.New(v.Type()).Elem()
+	p.Set(v)
+	return p.Addr()
+}
+
+// sliceValue binds every value of vals to the elements of typ, a slice type.
+func (r *Registry) sliceValue(vals []string, typ reflect.Type) reflect.Value {
+	slices := reflect.MakeSlice(typ, 0, len(vals))
+	for i := 0; i < len(vals); i++ {
+		val := r.bindValue(vals[i], typ.Elem())
+		slices = reflect.Append(slices, val.Convert(typ.Elem()))
+	}
+
+	return slices
+}
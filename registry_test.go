@@ -0,0 +1,55 @@
+package easybind
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cents int64
+
+type invoiceArgs struct {
+	Total   cents  `pos:"query:total"`
+	Limit   *cents `pos:"query:limit"`
+	Tenant  string `pos:"tenant:id"`
+	Comment string `pos:"query:comment,shout"`
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.TypeBinders[reflect.TypeOf(cents(0))] = func(val string, typ reflect.Type) reflect.Value {
+		return reflect.ValueOf(cents(len(val) * 100))
+	}
+	r.Transforms["shout"] = strings.ToUpper
+	r.RegisterSource(namedSource("tenant"))
+	assert.Panics(t, func() { r.RegisterSource(namedSource("header")) })
+
+	b := &Binder{Registry: r}
+	assert.Nil(t, b.Registry.Register(&invoiceArgs{}))
+	// the package registry knows neither the source nor the transform
+	assert.NotNil(t, Register(&invoiceArgs{}))
+	_, ok := Transforms["shout"]
+	assert.False(t, ok)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/invoices?total=12&limit=123&comment=hi", nil)
+	a := invoiceArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, cents(200), a.Total)
+	assert.Equal(t, cents(300), *a.Limit)
+	assert.Equal(t, "HI", a.Comment)
+
+	type args struct {
+		Total cents `pos:"query:total"`
+	}
+	assert.Nil(t, RegisterTags(&args{}, map[string]string{"Total": "query:amount"}))
+	other := args{}
+	assert.Nil(t, b.Bind(req, &other))
+	assert.Equal(t, cents(200), other.Total)
+
+	other = args{}
+	assert.Nil(t, Bind(req, &other))
+	assert.Equal(t, cents(0), other.Total)
+}
@@ -0,0 +1,147 @@
+package easybind
+
+import (
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// names of the request source, e.g. `pos:"request:client_ip"`
+const (
+	requestClientIP   = "client_ip"
+	requestRemoteAddr = "remote_addr"
+	requestMethod     = "method"
+	requestHost       = "host"
+	requestPath       = "path"
+)
+
+// requestValue returns the attribute name of the request itself.
+func (e *easyReq) requestValue(name string) string {
+	switch name {
+	case requestClientIP:
+		return e.binder.ClientIP(e.req)
+	case requestRemoteAddr:
+		return e.req.RemoteAddr
+	case requestMethod:
+		return e.req.Method
+	case requestHost:
+		return e.req.Host
+	case requestPath:
+		return e.req.URL.Path
+	}
+
+	return ""
+}
+
+// ClientIP returns the address of the client which sent req. Forwarded, X-Forwarded-For and X-Real-IP
+// are only honored when sent by one of b.TrustedProxies, the chain of proxies is walked from the
+// nearest one until an untrusted address.
+func (b *Binder) ClientIP(req *http.Request) string {
+	remote := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+
+	trusted := parseTrustedProxies(b.TrustedProxies)
+	if !isTrusted(trusted, remote) {
+		return remote
+	}
+
+	chain := forwardedFor(req.Header)
+	if len(chain) == 0 {
+		if realIP := strings.TrimSpace(req.Header.Get("X-Real-IP")); len(realIP) > 0 {
+			return realIP
+		}
+
+		return remote
+	}
+
+	for i := len(chain) - 1; i > 0; i-- {
+		if !isTrusted(trusted, chain[i]) {
+			return chain[i]
+		}
+	}
+
+	return chain[0]
+}
+
+// forwardedFor returns the addresses of the clients and proxies a request went through, client first,
+// from Forwarded or else X-Forwarded-For.
+func forwardedFor(h http.Header) (chain []string) {
+	for _, elem := range splitList(h.Values("Forwarded")) {
+		for _, pair := range strings.Split(elem, ";") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], "for") {
+				continue
+			}
+
+			chain = append(chain, stripPort(strings.Trim(kv[1], `"`)))
+		}
+	}
+
+	if len(chain) > 0 {
+		return
+	}
+
+	for _, addr := range splitList(h.Values("X-Forwarded-For")) {
+		chain = append(chain, stripPort(addr))
+	}
+
+	return
+}
+
+// stripPort strips the port of host:port or [ipv6]:port and the brackets of [ipv6].
+func stripPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
+
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil {
+				bits := 8 * len(ip.To16())
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 32
+				}
+				nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+			continue
+		}
+
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			nets = append(nets, n)
+		}
+	}
+
+	return nets
+}
+
+func isTrusted(trusted []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ipBinder(val string, typ reflect.Type) reflect.Value {
+	ip := net.ParseIP(strings.TrimSpace(val))
+	if ip == nil {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(ip)
+}
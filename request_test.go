@@ -0,0 +1,45 @@
+package easybind
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindClientIP(t *testing.T) {
+	type args struct {
+		ClientIP string `pos:"request:client_ip"`
+		IP       net.IP `pos:"request:client_ip"`
+		Method   string `pos:"request:method"`
+	}
+
+	b := &Binder{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"}}
+	cases := []struct {
+		remote   string
+		headers  map[string]string
+		expected string
+	}{
+		{"1.2.3.4:5678", map[string]string{"X-Forwarded-For": "9.9.9.9"}, "1.2.3.4"},
+		{"10.0.0.1:5678", nil, "10.0.0.1"},
+		{"10.0.0.1:5678", map[string]string{"X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"10.0.0.1:5678", map[string]string{"X-Forwarded-For": "9.9.9.9, 6.6.6.6, 192.168.1.1"}, "6.6.6.6"},
+		{"10.0.0.1:5678", map[string]string{"X-Forwarded-For": "9.9.9.9, 10.0.0.2"}, "9.9.9.9"},
+		{"10.0.0.1:5678", map[string]string{"Forwarded": `for=7.7.7.7;proto=https, for="[2001:db8::1]:4711"`, "X-Forwarded-For": "9.9.9.9"}, "2001:db8::1"},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+		req.RemoteAddr = c.remote
+		for k, v := range c.headers {
+			req.Header.Set(k, v)
+		}
+
+		a := args{}
+		assert.Nil(t, b.Bind(req, &a))
+		assert.Equal(t, c.expected, a.ClientIP)
+		assert.Equal(t, net.ParseIP(c.expected), a.IP)
+		assert.Equal(t, http.MethodGet, a.Method)
+	}
+}
@@ -0,0 +1,97 @@
+package easybind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	optionRequired        = "required"
+	optionRequiredIf      = "required_if"
+	optionRequiredWithout = "required_without"
+
+	// optionFieldSep separates field names in option values
+	optionFieldSep = "|"
+)
+
+// checkRequired fails on the first field of typ absent although required, embedded and nested structs included.
+func (e *easyReq) checkRequired(typ reflect.Type, nest *nesting) error {
+	for _, f := range e.registry.compile(typ).fields {
+		fieldType := f.fieldType
+		if embedded, ok := embeddedStruct(fieldType); ok {
+			if err := e.checkRequired(embedded, nest); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if f.pointed != nil {
+			if err := e.checkRequired(f.pointed, nest.pointed(fieldType.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if nest != nil {
+			var ok bool
+			if fieldType, ok = nest.apply(e.binder.named(fieldType)); !ok {
+				continue
+			}
+		}
+
+		fieldType = e.binder.named(profiled(fieldType, e.profile))
+		if nested, ok := nestedStruct(fieldType); ok {
+			inner := fieldType.Type
+			if inner.Kind() == reflect.Ptr {
+				inner = inner.Elem()
+			}
+			if inner.Kind() == reflect.Struct {
+				if err := e.checkRequired(inner, nested); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if e.fields[fieldType.Name] {
+			continue
+		}
+
+		if err := e.requiredErr(fieldType); err != nil {
+			loc, name := getInTagLocAndName(fieldType)
+			if loc == inTagBody && !isBodyPointer(name) {
+				name = jsonName(fieldType)
+			}
+
+			return &BindError{Field: fieldType.Name, Source: loc, Name: name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// requiredErr returns why the absent field is required, nil if it isn't.
+func (e *easyReq) requiredErr(fieldType reflect.StructField) error {
+	if hasInTagOption(fieldType, optionRequired) {
+		return ErrRequired
+	}
+
+	if others, ok := getInTagOption(fieldType, optionRequiredIf); ok {
+		for _, other := range strings.Split(others, optionFieldSep) {
+			if e.fields[other] {
+				return fmt.Errorf("%w if %s is set", ErrRequired, other)
+			}
+		}
+	}
+
+	if others, ok := getInTagOption(fieldType, optionRequiredWithout); ok {
+		for _, other := range strings.Split(others, optionFieldSep) {
+			if !e.fields[other] {
+				return fmt.Errorf("%w if %s isn't set", ErrRequired, other)
+			}
+		}
+	}
+
+	return nil
+}
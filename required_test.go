@@ -0,0 +1,42 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindRequired(t *testing.T) {
+	type args struct {
+		Keyword   string `pos:"query:keyword,required"`
+		StartDate string `pos:"query:start_date"`
+		EndDate   string `pos:"query:end_date,required_if=StartDate"`
+		Email     string `pos:"query:email,required_without=Phone"`
+		Phone     string `pos:"query:phone"`
+	}
+
+	cases := []struct {
+		query string
+		err   string
+	}{
+		{"keyword=go&phone=1", ""},
+		{"phone=1", "bind Keyword from query:keyword: field is required"},
+		{"keyword=go&email=a@b.c&start_date=2021-01-01", "bind EndDate from query:end_date: field is required if StartDate is set"},
+		{"keyword=go&email=a@b.c&start_date=2021-01-01&end_date=2021-02-01", ""},
+		{"keyword=go", "bind Email from query:email: field is required if Phone isn't set"},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users?"+c.query, nil)
+		err := Bind(req, &args{})
+		if len(c.err) == 0 {
+			assert.Nil(t, err, c.query)
+			continue
+		}
+
+		assert.True(t, errors.Is(err, ErrRequired), c.query)
+		assert.EqualError(t, err, c.err, c.query)
+	}
+}
@@ -0,0 +1,191 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrTooManyRows more indexes of a slice of structs than Binder.MaxElements.
+var ErrTooManyRows = errors.New("too many indexed parameters")
+
+// row the parameters of an element of a slice of structs, e.g. items.0.sku or items[0][sku].
+type row struct {
+	index  int
+	prefix string
+	suffix string
+}
+
+// isStructSlice reports whether typ is a slice of structs, or of pointers to structs, without binder.
+func (r *Registry) isStructSlice(typ reflect.Type) bool {
+	if _, ok := r.TypeBinders[typ]; ok || typ.Kind() != reflect.Slice {
+		return false
+	}
+
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	_, ok := r.TypeBinders[elem]
+	return !ok && elem.Kind() == reflect.Struct
+}
+
+// rowsOf returns the rows of name found in keys, by index, a row per index whatever the style.
+// Indexes don't need to be contiguous, the rows are compacted in order.
+func rowsOf(name string, keys []string) []row {
+	var (
+		seen = map[int]bool{}
+		rows []row
+	)
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		rest := strings.TrimPrefix(key, name)
+		if len(rest) == len(key) || len(rest) < 3 {
+			continue
+		}
+
+		var r row
+		switch rest[0] {
+		case '.':
+			end := strings.IndexByte(rest[1:], '.')
+			if end < 0 {
+				continue
+			}
+			digits := rest[1 : end+1]
+			if !isIndex(digits) {
+				continue
+			}
+			r.prefix = name + "." + digits + "."
+			r.index, _ = strconv.Atoi(digits)
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 || end+1 >= len(rest) || !isIndex(rest[1:end]) {
+				continue
+			}
+			digits := rest[1:end]
+			r.index, _ = strconv.Atoi(digits)
+			switch rest[end+1] {
+			case '[':
+				r.prefix, r.suffix = name+"["+digits+"][", "]"
+			case '.':
+				r.prefix = name + "[" + digits + "]."
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+
+		if !seen[r.index] {
+			seen[r.index] = true
+			rows = append(rows, r)
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].index < rows[j].index })
+	return rows
+}
+
+func isIndex(s string) bool {
+	if len(s) == 0 || len(s) > 9 {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sourceKeys returns the parameter names of the query or the form of the request.
+func (e *easyReq) sourceKeys(loc string) []string {
+	var values map[string][]string
+	switch loc {
+	case inTagQuery:
+		values = e.query
+	case inTagForm:
+		values, _ = e.postForm()
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// bindRows binds field, a slice of structs, from the indexed parameters name.N.field or name[N][field]
+// of the query or the form, an element per index.
+func (e *easyReq) bindRows(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) error {
+	rows := rowsOf(name, e.sourceKeys(loc))
+	if len(rows) == 0 {
+		ft.Skipped = "no value"
+		return nil
+	}
+
+	if max := e.binder.MaxElements; max > 0 && len(rows) > max {
+		return &BindError{Field: fieldType.Name, Source: loc, Name: name, Err: ErrTooManyRows}
+	}
+
+	var (
+		elemType = field.Type().Elem()
+		typ      = elemType
+		slice    = reflect.MakeSlice(field.Type(), 0, len(rows))
+	)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	for i, r := range rows {
+		elem := reflect.New(typ)
+		nest := &nesting{field: fmt.Sprintf("%s[%d]", fieldType.Name, i), source: loc, prefix: r.prefix, suffix: r.suffix}
+		if err := e.bindStruct(elem.Elem(), nest); err != nil {
+			return err
+		}
+		e.addRow(typ, nest)
+
+		if elemType.Kind() == reflect.Ptr {
+			slice = reflect.Append(slice, elem)
+		} else {
+			slice = reflect.Append(slice, elem.Elem())
+		}
+	}
+
+	ft.Conversion = fmt.Sprintf("%d rows", len(rows))
+	field.Set(slice)
+	e.setField(fieldType.Name, true)
+	return nil
+}
+
+// addRow records the element of a slice of structs, whose required fields checkRows checks.
+func (e *easyReq) addRow(typ reflect.Type, nest *nesting) {
+	e.mu.Lock()
+	e.rows = append(e.rows, nestedType{typ: typ, nest: nest})
+	e.mu.Unlock()
+}
+
+// checkRows fails on the first required field absent from an element of a slice of structs.
+func (e *easyReq) checkRows() error {
+	for _, r := range e.rows {
+		if err := e.checkRequired(r.typ, r.nest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nestedType a struct type bound as nest applies its fields.
+type nestedType struct {
+	typ  reflect.Type
+	nest *nesting
+}
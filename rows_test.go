@@ -0,0 +1,53 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderItem struct {
+	SKU string `pos:"form:sku,required"`
+	Qty int    `json:"qty"`
+}
+
+type orderArgs struct {
+	Items []orderItem  `pos:"form:items"`
+	Gifts []*orderItem `pos:"query:gifts"`
+	Notes []string     `pos:"form:notes"`
+}
+
+func TestBindRows(t *testing.T) {
+	assert.Nil(t, Register(&orderArgs{}))
+
+	form := url.Values{
+		"items.0.sku": {"a"}, "items.0.qty": {"2"},
+		"items.3.sku": {"b"},
+		"notes":       {"n"},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/orders?gifts[0][sku]=g&gifts[0][qty]=1&gifts[1].sku=h", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a := orderArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, []orderItem{{SKU: "a", Qty: 2}, {SKU: "b"}}, a.Items)
+	assert.Equal(t, []*orderItem{{SKU: "g", Qty: 1}, {SKU: "h"}}, a.Gifts)
+	assert.Equal(t, []string{"n"}, a.Notes)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/orders", strings.NewReader("items[0][qty]=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err := Bind(req, &orderArgs{})
+	var be *BindError
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, "Items[0].SKU", be.Field)
+	assert.Equal(t, "items[0][sku]", be.Name)
+	assert.ErrorIs(t, err, ErrRequired)
+
+	b := *DefaultBinder
+	b.MaxElements = 1
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/orders?gifts.0.sku=a&gifts.1.sku=b", nil)
+	assert.ErrorIs(t, b.Bind(req, &orderArgs{}), ErrTooManyRows)
+}
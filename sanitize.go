@@ -0,0 +1,79 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const optionSanitize = "sanitize"
+
+// Sanitizers registered sanitizers selected per field by `pos:"query:bio,sanitize=html"`,
+// `|` chains several. They replace Binder.Sanitize for that field. They are those of the package registry, see Registry.
+var Sanitizers = defaultRegistry.Sanitizers
+
+// StripControl removes control characters but tab and new lines from s.
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// MaxRunes returns a sanitizer truncating strings to n runes.
+func MaxRunes(n int) func(string) string {
+	return func(s string) string {
+		if utf8.RuneCountInString(s) <= n {
+			return s
+		}
+
+		return string([]rune(s)[:n])
+	}
+}
+
+// ChainSanitizers returns a sanitizer applying sanitizers in order.
+func ChainSanitizers(sanitizers ...func(string) string) func(string) string {
+	return func(s string) string {
+		for _, sanitize := range sanitizers {
+			s = sanitize(s)
+		}
+		return s
+	}
+}
+
+// sanitizeValues sanitizes a copy of values bound to a string field, or a pointer, slice or array of strings.
+func (e *easyReq) sanitizeValues(fieldType reflect.StructField, values []string) []string {
+	typ := fieldType.Type
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.String {
+		return values
+	}
+
+	sanitize := e.binder.Sanitize
+	if names, ok := getInTagOption(fieldType, optionSanitize); ok {
+		sanitizers := make([]func(string) string, 0, 1)
+		for _, name := range strings.Split(names, optionFieldSep) {
+			if s, ok := e.binder.registry().Sanitizers[name]; ok {
+				sanitizers = append(sanitizers, s)
+			}
+		}
+		sanitize = ChainSanitizers(sanitizers...)
+	}
+
+	if sanitize == nil {
+		return values
+	}
+
+	sanitized := make([]string, len(values))
+	for i, v := range values {
+		sanitized[i] = sanitize(v)
+	}
+
+	return sanitized
+}
@@ -0,0 +1,20 @@
+package easybind
+
+import (
+	"net/http"
+)
+
+// Source a custom source of pos tags, e.g. a session store bound by `pos:"session:user_id"`.
+type Source interface {
+	// Name source name in pos tags
+	Name() string
+	// Values returns the values of name in req, none if absent.
+	// An error fails the binding as a *BindError.
+	Values(req *http.Request, name string) ([]string, error)
+}
+
+// RegisterSource makes src available to pos tags, registering a name again replaces its source.
+// Register sources before the params structs using them, RegisterSource panics on the name of a builtin source.
+func RegisterSource(src Source) {
+	defaultRegistry.RegisterSource(src)
+}
@@ -0,0 +1,54 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type storeSource map[string][]string
+
+func (s storeSource) Name() string { return "store" }
+
+func (s storeSource) Values(req *http.Request, name string) ([]string, error) {
+	if req.Header.Get("X-Session") != "valid" {
+		return nil, errors.New("no session")
+	}
+
+	return s[name], nil
+}
+
+func TestRegisterSource(t *testing.T) {
+	// a local registry, the package one is shared by every test
+	r := NewRegistry()
+	r.RegisterSource(storeSource{"user_id": {"42"}})
+	assert.Panics(t, func() { r.RegisterSource(namedSource("query")) })
+	b := &Binder{Registry: r}
+
+	type args struct {
+		UserID int    `pos:"store:user_id"`
+		Role   string `pos:"store:role"`
+	}
+	assert.Nil(t, r.Register(&args{}))
+	assert.True(t, errors.Is(Register(&args{}), ErrInvalidTag))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+	req.Header.Set("X-Session", "valid")
+
+	a := args{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, args{UserID: 42}, a)
+
+	req.Header.Del("X-Session")
+	var bindErr *BindError
+	assert.True(t, errors.As(b.Bind(req, &args{}), &bindErr))
+	assert.Equal(t, "store", bindErr.Source)
+}
+
+type namedSource string
+
+func (s namedSource) Name() string { return string(s) }
+
+func (s namedSource) Values(*http.Request, string) ([]string, error) { return nil, nil }
@@ -0,0 +1,96 @@
+package easybind
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// optionAllow the fields a SparseFields field may request, e.g. allow=title|author|comments.body,
+// comments.* allows every field of comments.
+const optionAllow = "allow"
+
+// ErrFieldNotAllowed a sparse fieldset requests a field its allowlist doesn't have.
+var ErrFieldNotAllowed = errors.New("field not allowed")
+
+var sparseFieldsType = reflect.TypeOf(SparseFields{})
+
+// SparseFields sparse fieldsets shaping the response, e.g. ?fields=author,title&fields[comments]=body,
+// bind with `pos:"query:fields,allow=author|title|comments.body"`.
+type SparseFields struct {
+	// Fields of the primary resource, e.g. fields=author,title
+	Fields []string
+	// Types fields by related resource type, e.g. fields[comments]=body
+	Types map[string][]string
+}
+
+// Has reports whether field of the primary resource is requested, every field is if none is.
+func (s SparseFields) Has(field string) bool {
+	return len(s.Fields) == 0 || contains(s.Fields, field)
+}
+
+// HasOf reports whether field of the resource type typ is requested, every field is if none of typ is.
+func (s SparseFields) HasOf(typ, field string) bool {
+	fields, ok := s.Types[typ]
+	return !ok || contains(fields, field)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bindSparseFields binds field, a SparseFields, from the query parameters name and name[type], checking
+// the requested fields against the allow option if any.
+func (e *easyReq) bindSparseFields(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) error {
+	s := SparseFields{Fields: splitList(e.query[name])}
+	for typ, values := range groupValues(e.query, name) {
+		if s.Types == nil {
+			s.Types = make(map[string][]string)
+		}
+		s.Types[typ] = splitList(values)
+	}
+
+	if len(s.Fields) == 0 && len(s.Types) == 0 {
+		ft.Skipped = "no value"
+		return nil
+	}
+
+	if allow, ok := getInTagOption(fieldType, optionAllow); ok {
+		allowed := strings.Split(allow, optionFieldSep)
+		notAllowed := func(path string) error {
+			ft.Skipped = "field " + path + " not allowed"
+			return &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: path, Err: ErrFieldNotAllowed}
+		}
+
+		for _, f := range s.Fields {
+			if !contains(allowed, f) {
+				return notAllowed(f)
+			}
+		}
+		types := make([]string, 0, len(s.Types))
+		for typ := range s.Types {
+			types = append(types, typ)
+		}
+		// checked in order, so the same request always fails the same way
+		sort.Strings(types)
+		for _, typ := range types {
+			for _, f := range s.Types[typ] {
+				if !contains(allowed, typ+"."+f) && !contains(allowed, typ+".*") {
+					return notAllowed(typ + "." + f)
+				}
+			}
+		}
+	}
+
+	ft.Conversion = "sparse fieldsets"
+	e.setField(fieldType.Name, true)
+	field.Set(reflect.ValueOf(s))
+	return nil
+}
@@ -0,0 +1,50 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listArticlesArgs struct {
+	Fields SparseFields `pos:"query:fields,allow=title|author|comments.body|people.*"`
+}
+
+func TestBindSparseFields(t *testing.T) {
+	assert.Nil(t, Register(&listArticlesArgs{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/articles?fields=title,author&fields[comments]=body&fields[people]=name", nil)
+	a := listArticlesArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Equal(t, SparseFields{
+		Fields: []string{"title", "author"},
+		Types:  map[string][]string{"comments": {"body"}, "people": {"name"}},
+	}, a.Fields)
+	assert.True(t, a.Fields.Has("title"))
+	assert.False(t, a.Fields.Has("body"))
+	assert.True(t, a.Fields.HasOf("comments", "body"))
+	assert.False(t, a.Fields.HasOf("comments", "author"))
+	assert.True(t, a.Fields.HasOf("tags", "name"))
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/articles", nil)
+	a = listArticlesArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.True(t, a.Fields.Has("body"))
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/articles?fields[comments]=secret", nil)
+	assert.ErrorIs(t, Bind(req, &listArticlesArgs{}), ErrFieldNotAllowed)
+
+	// the first field not allowed, by resource type, is reported
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/articles?fields[tags]=x&fields[comments]=secret&fields[blogs]=y", nil)
+	for i := 0; i < 10; i++ {
+		bindErr := &BindError{}
+		assert.ErrorAs(t, Bind(req, &listArticlesArgs{}), &bindErr)
+		assert.Equal(t, "blogs.y", bindErr.Value)
+	}
+
+	type formArgs struct {
+		Fields SparseFields `pos:"form:fields"`
+	}
+	assert.ErrorIs(t, Register(&formArgs{}), ErrInvalidTag)
+}
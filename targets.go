@@ -0,0 +1,63 @@
+package easybind
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Targets params structs bound from the same request, in order, e.g. parameter groups shared by many endpoints:
+//
+//	var (
+//		auth    AuthParams
+//		page    Pagination
+//		payload CreateOrderArgs
+//	)
+//	err := easybind.Bind(req, easybind.Targets{&auth, &page, &payload}, pathParams)
+//
+// Every target is bound as if alone, its validation included, from the same body, read once.
+// The binding stops at the first target failing, Binder.Timeout bounds the binding of each.
+type Targets []interface{}
+
+// bindTargets binds every target of targets from req, and returns the fields populated in any of them.
+func (b *Binder) bindTargets(req *http.Request, targets Targets, pathQueryier []interface{}, trace *Trace) (FieldSet, error) {
+	var data []byte
+	if req.Body != nil && req.Body != http.NoBody && !isFormBody(req) {
+		// forms are parsed once into the request, other bodies are read again by every target
+		var err error
+		dl := b.bindDeadline(req)
+		data, err = b.readAll(req.Body)
+		if timeoutErr := dl.err(); timeoutErr != nil {
+			err = timeoutErr
+		}
+		dl.release()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := FieldSet{}
+	for _, params := range targets {
+		if data != nil {
+			restoreBody(req, data)
+		}
+
+		bound, err := b.bind(req, params, pathQueryier, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		for name := range bound {
+			fields[name] = true
+		}
+	}
+
+	return fields, nil
+}
+
+// isFormBody reports whether req has a form body, url encoded or multipart.
+func isFormBody(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	mediaType = strings.ToLower(mediaType)
+	return mediaType == mediaTypeForm || mediaType == mediaTypeMultipart
+}
@@ -0,0 +1,68 @@
+package easybind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type authParams struct {
+	Token string `pos:"header:Authorization,required"`
+}
+
+type pagination struct {
+	Page int `pos:"query:page,default=1"`
+	Size int `pos:"query:size,default=20"`
+}
+
+type createOrderArgs struct {
+	StoreID string `pos:"path:store_id"`
+	SKU     string `json:"sku"`
+	Count   int    `json:"count"`
+}
+
+func TestBindTargets(t *testing.T) {
+	newReq := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/stores/1/orders?size=50", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer t")
+		return req
+	}
+	pathParams := map[string]string{"store_id": "1"}
+
+	var (
+		auth    authParams
+		page    pagination
+		payload createOrderArgs
+		echo    createOrderArgs
+	)
+	b := &Binder{PreserveBody: true}
+	fields, err := b.BindFieldSet(newReq(`{"sku": "A1", "count": 2}`), Targets{&auth, &page, &payload, &echo}, pathParams)
+	assert.Nil(t, err)
+	assert.Equal(t, authParams{Token: "Bearer t"}, auth)
+	assert.Equal(t, pagination{Page: 1, Size: 50}, page)
+	assert.Equal(t, createOrderArgs{StoreID: "1", SKU: "A1", Count: 2}, payload)
+	assert.Equal(t, payload, echo)
+	assert.Equal(t, FieldSet{"Token": true, "Page": true, "Size": true, "StoreID": true, "SKU": true, "Count": true}, fields)
+
+	req := newReq(`{"sku": "A1"}`)
+	req.Header.Del("Authorization")
+	err = Bind(req, Targets{&pagination{}, &authParams{}, &createOrderArgs{}}, pathParams)
+	assert.True(t, errors.Is(err, ErrRequired))
+	bindErr := &BindError{}
+	assert.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "Token", bindErr.Field)
+
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/stores/1/orders?page=3", strings.NewReader("size=10"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	type sizeForm struct {
+		Size int `pos:"form:size"`
+	}
+	page, form := pagination{}, sizeForm{}
+	assert.Nil(t, Bind(req, Targets{&page, &form}))
+	assert.Equal(t, pagination{Page: 3, Size: 20}, page)
+	assert.Equal(t, sizeForm{Size: 10}, form)
+}
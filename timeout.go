@@ -0,0 +1,140 @@
+package easybind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TimeoutError binding took longer than Binder.Timeout, or the context of the request was done first,
+// e.g. the client went away.
+type TimeoutError struct {
+	// After Binder.Timeout, 0 if the binding had no timeout
+	After time.Duration
+	// Err context.DeadlineExceeded or context.Canceled
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.After > 0 {
+		return fmt.Sprintf("binding aborted after %s: %v", e.After, e.Err)
+	}
+
+	return fmt.Sprintf("binding aborted: %v", e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode the HTTP status code to respond with: 408 if the binding took longer than Binder.Timeout,
+// e.g. reading the body of a slow client, 503 if the context of the request was done first.
+func (e *TimeoutError) StatusCode() int {
+	if e.After > 0 && e.Timeout() {
+		return http.StatusRequestTimeout
+	}
+
+	return http.StatusServiceUnavailable
+}
+
+// Timeout reports whether the binding timed out rather than was canceled, as net.Error.
+func (e *TimeoutError) Timeout() bool {
+	return errors.Is(e.Err, context.DeadlineExceeded)
+}
+
+// deadline bounds the binding of a request: done when the context of the request is, or once Binder.Timeout elapsed.
+type deadline struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	after   time.Duration
+	req     *http.Request
+	body    io.ReadCloser
+	wrapped io.ReadCloser
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// bindDeadline returns the deadline of binding req. The reads of its body fail with a *TimeoutError once done,
+// and a body read on timeout is closed, so slow clients can't hold the binding. release restores the body.
+func (b *Binder) bindDeadline(req *http.Request) *deadline {
+	d := &deadline{after: b.Timeout, req: req, done: make(chan struct{})}
+	if b.Timeout > 0 {
+		d.ctx, d.cancel = context.WithTimeout(req.Context(), b.Timeout)
+	} else {
+		d.ctx, d.cancel = context.WithCancel(req.Context())
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return d
+	}
+
+	d.body = req.Body
+	d.wrapped = &deadlineBody{ReadCloser: req.Body, d: d}
+	req.Body = d.wrapped
+	if b.Timeout > 0 {
+		d.wg.Add(1)
+		go d.closeOnTimeout()
+	}
+
+	return d
+}
+
+// closeOnTimeout closes the body once the deadline is done, unless released first, to abort a pending read.
+func (d *deadline) closeOnTimeout() {
+	defer d.wg.Done()
+	select {
+	case <-d.done:
+	case <-d.ctx.Done():
+		select {
+		case <-d.done:
+		default:
+			d.body.Close()
+		}
+	}
+}
+
+// err returns the *TimeoutError of d once done, nil before.
+func (d *deadline) err() error {
+	if err := d.ctx.Err(); err != nil {
+		return &TimeoutError{After: d.after, Err: err}
+	}
+
+	return nil
+}
+
+// release ends the binding: it stops closeOnTimeout and restores the body of the request, unless replaced,
+// e.g. by PreserveBody.
+func (d *deadline) release() {
+	close(d.done)
+	d.cancel()
+	d.wg.Wait()
+	if d.wrapped != nil && d.req.Body == d.wrapped {
+		d.req.Body = d.body
+	}
+}
+
+// deadlineBody a body whose reads fail once its deadline is done.
+type deadlineBody struct {
+	io.ReadCloser
+	d *deadline
+}
+
+func (b *deadlineBody) Read(p []byte) (int, error) {
+	if err := b.d.err(); err != nil {
+		return 0, err
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		if timeoutErr := b.d.err(); timeoutErr != nil {
+			// the read failed as the body was closed on timeout
+			return n, timeoutErr
+		}
+	}
+
+	return n, err
+}
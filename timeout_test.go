@@ -0,0 +1,80 @@
+package easybind
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowSource struct{}
+
+func (slowSource) Name() string { return "slow" }
+
+func (slowSource) Values(req *http.Request, name string) ([]string, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestBindTimeout(t *testing.T) {
+	type args struct {
+		ID   int    `pos:"query:id"`
+		Name string `json:"name"`
+	}
+
+	goroutines := runtime.NumGoroutine()
+	b := &Binder{Timeout: 50 * time.Millisecond}
+
+	// a client never sending its body
+	body, w := io.Pipe()
+	defer w.Close()
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?id=1", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = 64
+
+	start := time.Now()
+	err := b.Bind(req, &args{})
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.True(t, timeoutErr.Timeout())
+	assert.Equal(t, b.Timeout, timeoutErr.After)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	r := NewRegistry()
+	r.RegisterSource(slowSource{})
+	b.Registry = r
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/users?id=1", nil)
+	assert.True(t, errors.As(b.Bind(req, &struct {
+		ID      int    `pos:"query:id"`
+		Session string `pos:"slow:session"`
+	}{}), &timeoutErr))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, "https://hello.world/users?id=1", strings.NewReader(`{"name": "bob"}`))
+	err = (&Binder{}).Bind(req, &args{})
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.False(t, timeoutErr.Timeout())
+
+	// the body of a request bound in time is read as usual
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/users?id=1", strings.NewReader(`{"name": "bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+	a := args{}
+	assert.Nil(t, (&Binder{Timeout: time.Second, PreserveBody: true}).Bind(req, &a))
+	assert.Equal(t, args{ID: 1, Name: "bob"}, a)
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"name": "bob"}`, string(data))
+
+	// no goroutine outlives the bindings, polled as assert.Eventually runs its own
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
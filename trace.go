@@ -0,0 +1,116 @@
+package easybind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// FieldTrace records how one field was bound.
+type FieldTrace struct {
+	// Field struct field name
+	Field string
+	// Source where the value was looked up: path, query, header, form or body
+	Source string
+	// Name parameter name consulted in Source
+	Name string
+	// Raw values found in Source
+	Raw []string
+	// Conversion binder applied to Raw, e.g. int, time.Time, slice of string
+	Conversion string
+	// Skipped why the field was left untouched, empty if it was bound
+	Skipped string
+}
+
+func (f FieldTrace) String() string {
+	s := fmt.Sprintf("%s <- %s:%s %q", f.Field, f.Source, f.Name, f.Raw)
+	if len(f.Conversion) > 0 {
+		s += " as " + f.Conversion
+	}
+
+	if len(f.Skipped) > 0 {
+		s += " skipped: " + f.Skipped
+	}
+
+	return s
+}
+
+// Trace records how every field of a params struct was bound.
+type Trace struct {
+	mu     sync.Mutex
+	Fields []FieldTrace
+}
+
+func (t *Trace) add(f FieldTrace) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.Fields = append(t.Fields, f)
+	t.mu.Unlock()
+}
+
+// Field returns the trace of the field named name.
+func (t *Trace) Field(name string) (f FieldTrace, ok bool) {
+	for _, f = range t.Fields {
+		if f.Field == name {
+			return f, true
+		}
+	}
+
+	return FieldTrace{}, false
+}
+
+func (t *Trace) String() string {
+	lines := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		lines = append(lines, f.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// TraceError returned by a Binder in Debug mode when binding fails.
+type TraceError struct {
+	Trace *Trace
+	Err   error
+}
+
+func (e *TraceError) Error() string {
+	return e.Err.Error() + "\n" + e.Trace.String()
+}
+
+func (e *TraceError) Unwrap() error {
+	return e.Err
+}
+
+// BindTrace same as Bind, but also returns how every field was bound.
+func BindTrace(req *http.Request, params interface{}, pathQueryier ...interface{}) (*Trace, error) {
+	return DefaultBinder.BindTrace(req, params, pathQueryier...)
+}
+
+// BindTrace same as BindTrace, but use b's configuration.
+func (b *Binder) BindTrace(req *http.Request, params interface{}, pathQueryier ...interface{}) (trace *Trace, err error) {
+	trace = &Trace{}
+	_, err = b.bind(req, params, pathQueryier, trace)
+	return
+}
+
+func (r *Registry) describeBinder(typ reflect.Type) string {
+	if _, ok := r.TypeBinders[typ]; ok {
+		return typ.String()
+	}
+
+	if _, ok := r.KindBinders[typ.Kind()]; ok {
+		if typ.Kind() == reflect.Ptr {
+			return "pointer to " + r.describeBinder(typ.Elem())
+		}
+
+		return typ.Kind().String()
+	}
+
+	return ""
+}
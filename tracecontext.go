@@ -0,0 +1,126 @@
+package easybind
+
+import (
+	"encoding/hex"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// TraceParent W3C trace context traceparent header, bind with `pos:"header:traceparent"`.
+// A malformed header binds the zero value, check IsValid.
+type TraceParent struct {
+	Version  byte
+	TraceID  string
+	ParentID string
+	Flags    byte
+}
+
+// IsValid reports whether p was parsed from a well formed header.
+func (p TraceParent) IsValid() bool {
+	return len(p.TraceID) == 32 && len(p.ParentID) == 16
+}
+
+// Sampled reports whether the caller may have recorded the trace.
+func (p TraceParent) Sampled() bool {
+	return p.Flags&0x01 == 0x01
+}
+
+func (p TraceParent) String() string {
+	return hex.EncodeToString([]byte{p.Version}) + "-" + p.TraceID + "-" + p.ParentID + "-" + hex.EncodeToString([]byte{p.Flags})
+}
+
+// TraceStateMember one key=value pair of tracestate.
+type TraceStateMember struct {
+	Key   string
+	Value string
+}
+
+// TraceState W3C trace context tracestate header, bind with `pos:"header:tracestate"`.
+type TraceState []TraceStateMember
+
+// Get returns the value of key.
+func (s TraceState) Get(key string) string {
+	for _, m := range s {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+
+	return ""
+}
+
+// RequestIDHeaders headers carrying a request id, in the order RequestID looks them up.
+var RequestIDHeaders = []string{"X-Request-ID", "X-Correlation-ID", "Request-Id"}
+
+// RequestID returns the first request id set by RequestIDHeaders.
+func RequestID(req *http.Request) string {
+	for _, h := range RequestIDHeaders {
+		if id := req.Header.Get(h); len(id) > 0 {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func parseTraceParent(val string) (p TraceParent, ok bool) {
+	parts := strings.Split(strings.TrimSpace(val), "-")
+	if len(parts) < 4 {
+		return
+	}
+
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 || version[0] == 0xff || version[0] == 0 && len(parts) != 4 {
+		return
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return
+	}
+
+	if !isLowerHex(parts[1], 32) || !isLowerHex(parts[2], 16) {
+		return
+	}
+
+	return TraceParent{Version: version[0], TraceID: parts[1], ParentID: parts[2], Flags: flags[0]}, true
+}
+
+// isLowerHex reports whether s is n lowercase hex digits, not all zeros.
+func isLowerHex(s string, n int) bool {
+	if len(s) != n || strings.Trim(s, "0") == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func traceParentBinder(val string, typ reflect.Type) reflect.Value {
+	p, ok := parseTraceParent(val)
+	if !ok {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(p)
+}
+
+func traceStateBinder(val string, typ reflect.Type) reflect.Value {
+	list := splitList([]string{val})
+	state := make(TraceState, 0, len(list))
+	for _, s := range list {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		state = append(state, TraceStateMember{Key: strings.TrimSpace(kv[0]), Value: strings.TrimSpace(kv[1])})
+	}
+
+	return reflect.ValueOf(state)
+}
@@ -0,0 +1,45 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindTraceContext(t *testing.T) {
+	type args struct {
+		Parent    TraceParent `pos:"header:traceparent"`
+		State     TraceState  `pos:"header:tracestate"`
+		RequestID string      `pos:"header:X-Request-ID"`
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Add("tracestate", "rojo=00f067aa0ba902b7")
+	req.Header.Add("tracestate", "congo=t61rcWkgMzE")
+	req.Header.Set("X-Request-ID", "abc")
+
+	a := args{}
+	assert.Nil(t, Bind(req, &a))
+	assert.True(t, a.Parent.IsValid())
+	assert.True(t, a.Parent.Sampled())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", a.Parent.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", a.Parent.ParentID)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", a.Parent.String())
+	assert.Equal(t, "t61rcWkgMzE", a.State.Get("congo"))
+	assert.Equal(t, "abc", a.RequestID)
+	assert.Equal(t, "abc", RequestID(req))
+
+	for _, invalid := range []string{
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+	} {
+		req.Header.Set("traceparent", invalid)
+		a := args{}
+		assert.Nil(t, Bind(req, &a))
+		assert.False(t, a.Parent.IsValid(), invalid)
+	}
+}
@@ -0,0 +1,360 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidTemplate a malformed path template of an HTTPRule.
+	ErrInvalidTemplate = errors.New("invalid path template")
+	// ErrPathMismatch the path of the request doesn't match the template of the HTTPRule.
+	ErrPathMismatch = errors.New("path doesn't match template")
+)
+
+// HTTPRule google.api.http mapping of a request message, so easybind transcodes the HTTP requests
+// of Connect, Twirp or grpc-gateway style services into messages without pos tags.
+// The variables of the template set the fields of their path, e.g. {user.id} sets User.Id, the query
+// parameters the others, e.g. ?user.name=bob, unless the body is the whole message.
+type HTTPRule struct {
+	// Pattern path template, e.g. /v1/{name=shelves/*}/books/{book.id}:publish
+	Pattern string
+	// Body path of the field the json body binds, * for the whole message, none if empty
+	Body string
+}
+
+// templateSegment a segment of a path template: a literal, * for any segment or ** for any number of them,
+// captured by the variable field if set.
+type templateSegment struct {
+	literal string
+	field   string
+}
+
+type pathTemplate struct {
+	segments []templateSegment
+	verb     string
+}
+
+var templates sync.Map // string -> *pathTemplate
+
+// BindRule binds req into params, a message of the request, as mapped by rule.
+//
+//	rule := easybind.HTTPRule{Pattern: "/v1/users/{user.id}", Body: "user"}
+//	err := easybind.BindRule(req, rule, &pb.UpdateUserRequest{})
+func BindRule(req *http.Request, rule HTTPRule, params interface{}) error {
+	return DefaultBinder.BindRule(req, rule, params)
+}
+
+// BindRule same as BindRule, by the configuration of b.
+func (b *Binder) BindRule(req *http.Request, rule HTTPRule, params interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r, nil, params)
+		}
+	}()
+
+	dl := b.bindDeadline(req)
+	defer func() {
+		if timeoutErr := dl.err(); timeoutErr != nil {
+			err = timeoutErr
+		}
+		dl.release()
+	}()
+
+	tmpl, err := parseTemplate(rule.Pattern)
+	if err != nil {
+		return err
+	}
+
+	vars, ok := tmpl.match(req.URL.EscapedPath())
+	if !ok {
+		return fmt.Errorf("%w %s: %s", ErrPathMismatch, rule.Pattern, req.URL.Path)
+	}
+
+	val := reflect.ValueOf(params)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("can't bind to nonpointer value")
+	}
+
+	readBody, err := b.checkBody(req)
+	if err != nil {
+		return err
+	}
+
+	if len(rule.Body) > 0 && readBody && req.ContentLength != 0 && req.Body != nil {
+		if err = b.checkMediaType(req, mediaTypeJSON); err != nil {
+			return err
+		}
+
+		if err = b.bindRuleBody(req, rule.Body, val); err != nil {
+			return err
+		}
+	}
+
+	if rule.Body != "*" {
+		query, err := b.query(req)
+		if err != nil {
+			return err
+		}
+
+		for name, values := range query {
+			if _, ok := vars[name]; ok {
+				continue
+			}
+			// unknown parameters are ignored, as by the json body
+			if err = b.registry().setFieldPath(val, name, values); err != nil && !errors.Is(err, errUnknownField) {
+				return &BindError{Field: name, Source: inTagQuery, Name: name, Value: strings.Join(values, tagSep), Err: err}
+			}
+		}
+	}
+
+	for name, value := range vars {
+		if err = b.registry().setFieldPath(val, name, []string{value}); err != nil {
+			return &BindError{Field: name, Source: inTagPath, Name: name, Value: value, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// bindRuleBody decodes the json body of req into the field of val at path, val itself for *.
+func (b *Binder) bindRuleBody(req *http.Request, path string, val reflect.Value) error {
+	data, err := b.readBody(req)
+	if err != nil {
+		return err
+	}
+
+	target := val
+	if path != "*" {
+		if target, err = fieldPath(val, path); err != nil {
+			return &BindError{Field: path, Source: inTagBody, Name: path, Err: err}
+		}
+		target = target.Addr()
+	}
+
+	_, err = b.decodeJSON(data, target.Interface())
+	return err
+}
+
+var errUnknownField = errors.New("unknown field")
+
+// setFieldPath sets the field of val at path, e.g. user.id, from values, allocating nil pointers on the way.
+func (r *Registry) setFieldPath(val reflect.Value, path string, values []string) error {
+	field, err := fieldPath(val, path)
+	if err != nil {
+		return err
+	}
+
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+
+	_, hasType := r.TypeBinders[field.Type()]
+	switch {
+	case !r.hasBinder(field.Type()):
+		return fmt.Errorf("no binder for %s", field.Type())
+	case field.Kind() == reflect.Slice && !hasType:
+		field.Set(reflect.AppendSlice(field, r.sliceValue(values, field.Type())))
+	default:
+		field.Set(r.bindValue(values[0], field.Type()).Convert(field.Type()))
+	}
+
+	return nil
+}
+
+// fieldPath returns the field of val, a pointer to struct, at path, its names separated by dots.
+// A name matches the json or protobuf name of a field, or its Go name case insensitively.
+func fieldPath(val reflect.Value, path string) (reflect.Value, error) {
+	for _, name := range strings.Split(path, ".") {
+		for val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%w %s of %s", errUnknownField, name, val.Type())
+		}
+
+		index, ok := messageField(val.Type(), name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%w %s of %s", errUnknownField, name, val.Type())
+		}
+		val = val.Field(index)
+	}
+
+	return val, nil
+}
+
+// messageField returns the index of the exported field of typ named name.
+func messageField(typ reflect.Type, name string) (int, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if len(fieldType.PkgPath) > 0 {
+			continue
+		}
+
+		if jsonName(fieldType) == name || strings.EqualFold(fieldType.Name, name) {
+			return i, true
+		}
+
+		for _, option := range strings.Split(fieldType.Tag.Get("protobuf"), tagSep) {
+			if option == "name="+name || option == "json="+name {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// parseTemplate returns the cached template of pattern, e.g. /v1/{name=shelves/*}/books/{book.id}:publish.
+func parseTemplate(pattern string) (*pathTemplate, error) {
+	if tmpl, ok := templates.Load(pattern); ok {
+		return tmpl.(*pathTemplate), nil
+	}
+
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidTemplate, pattern, reason)
+	}
+
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, invalid("want a leading /")
+	}
+
+	tmpl := &pathTemplate{}
+	rest := pattern[1:]
+	if i := strings.LastIndex(rest, ":"); i >= 0 && !strings.ContainsAny(rest[i:], "/}") {
+		rest, tmpl.verb = rest[:i], rest[i+1:]
+	}
+
+	for len(rest) > 0 {
+		var segment string
+		if strings.HasPrefix(rest, "{") {
+			end := strings.Index(rest, "}")
+			if end < 0 {
+				return nil, invalid("unclosed variable")
+			}
+
+			field, segments := rest[1:end], "*"
+			if i := strings.Index(field, "="); i >= 0 {
+				field, segments = field[:i], field[i+1:]
+			}
+			if len(field) == 0 || strings.ContainsAny(segments, "{}") {
+				return nil, invalid("malformed variable " + rest[:end+1])
+			}
+
+			for _, s := range strings.Split(segments, "/") {
+				tmpl.segments = append(tmpl.segments, templateSegment{literal: s, field: field})
+			}
+			segment, rest = rest[:end+1], rest[end+1:]
+		} else {
+			end := strings.Index(rest, "/")
+			if end < 0 {
+				end = len(rest)
+			}
+			segment, rest = rest[:end], rest[end:]
+			if strings.ContainsAny(segment, "{}") {
+				return nil, invalid("malformed segment " + segment)
+			}
+			tmpl.segments = append(tmpl.segments, templateSegment{literal: segment})
+		}
+
+		if len(segment) == 0 {
+			return nil, invalid("empty segment")
+		}
+
+		if len(rest) > 0 {
+			if rest[0] != '/' || len(rest) == 1 {
+				return nil, invalid("malformed segment " + segment + rest)
+			}
+			rest = rest[1:]
+		}
+	}
+
+	actual, _ := templates.LoadOrStore(pattern, tmpl)
+	return actual.(*pathTemplate), nil
+}
+
+// match returns the values of the variables of t in path, an escaped path, ok is false if it doesn't match.
+func (t *pathTemplate) match(path string) (vars map[string]string, ok bool) {
+	path = strings.TrimPrefix(path, "/")
+	if len(t.verb) > 0 {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+		path = strings.TrimSuffix(path, ":"+t.verb)
+	}
+
+	var segments []string
+	if len(path) > 0 {
+		segments = strings.Split(path, "/")
+	}
+
+	captured := make([][]string, len(t.segments))
+	if !t.matchFrom(0, segments, captured) {
+		return nil, false
+	}
+
+	vars = make(map[string]string)
+	for i, s := range t.segments {
+		if len(s.field) == 0 {
+			continue
+		}
+
+		value := strings.Join(captured[i], "/")
+		if prev, ok := vars[s.field]; ok {
+			value = prev + "/" + value
+		}
+		vars[s.field] = value
+	}
+
+	return vars, true
+}
+
+// matchFrom matches segments with the template segments from i on, recording the path segments
+// each captured, unescaped.
+func (t *pathTemplate) matchFrom(i int, segments []string, captured [][]string) bool {
+	if i == len(t.segments) {
+		return len(segments) == 0
+	}
+
+	s := t.segments[i]
+	if s.literal == "**" {
+		for n := len(segments); n >= 0; n-- {
+			if t.matchFrom(i+1, segments[n:], captured) {
+				captured[i] = unescapeSegments(segments[:n])
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 || s.literal != "*" && s.literal != segments[0] {
+		return false
+	}
+
+	captured[i] = unescapeSegments(segments[:1])
+	return t.matchFrom(i+1, segments[1:], captured)
+}
+
+func unescapeSegments(segments []string) []string {
+	unescaped := make([]string, len(segments))
+	for i, s := range segments {
+		if u, err := url.PathUnescape(s); err == nil {
+			s = u
+		}
+		unescaped[i] = s
+	}
+
+	return unescaped
+}
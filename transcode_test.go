@@ -0,0 +1,49 @@
+package easybind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pbUser struct {
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName string   `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Tags        []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+type pbUpdateUserRequest struct {
+	Parent string  `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	User   *pbUser `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Force  bool    `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func TestBindRule(t *testing.T) {
+	rule := HTTPRule{Pattern: "/v1/{parent=orgs/*}/users/{user.id}:update", Body: "user"}
+	req, _ := http.NewRequest(http.MethodPatch, "https://hello.world/v1/orgs/acme/users/u%201:update?force=true&user.tags=a&user.tags=b&other=1",
+		strings.NewReader(`{"display_name": "Bob", "id": "ignored"}`))
+
+	m := pbUpdateUserRequest{}
+	assert.Nil(t, BindRule(req, rule, &m))
+	assert.Equal(t, pbUpdateUserRequest{
+		Parent: "orgs/acme",
+		User:   &pbUser{Id: "u 1", DisplayName: "Bob", Tags: []string{"a", "b"}},
+		Force:  true,
+	}, m)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/v1/orgs/acme/users/1?user.displayName=Bob", nil)
+	m = pbUpdateUserRequest{}
+	assert.Nil(t, BindRule(req, HTTPRule{Pattern: "/v1/{parent=orgs/*}/users/{user.id}"}, &m))
+	assert.Equal(t, &pbUser{Id: "1", DisplayName: "Bob"}, m.User)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://hello.world/v1/files/a/b/c", nil)
+	m = pbUpdateUserRequest{}
+	assert.Nil(t, BindRule(req, HTTPRule{Pattern: "/v1/files/{parent=**}"}, &m))
+	assert.Equal(t, "a/b/c", m.Parent)
+
+	assert.ErrorIs(t, BindRule(req, rule, &m), ErrPathMismatch)
+	assert.ErrorIs(t, BindRule(req, HTTPRule{Pattern: "/v1/{parent"}, &m), ErrInvalidTemplate)
+	assert.ErrorIs(t, BindRule(req, HTTPRule{Pattern: "/v1/files/{missing=**}"}, &m), errUnknownField)
+}
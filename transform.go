@@ -0,0 +1,35 @@
+package easybind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Transforms string transforms applied to raw values in the order of the pos tag options,
+// before conversion, e.g. `pos:"query:email,trim,lower"`.
+// They are those of the package registry, see Registry.
+var Transforms = defaultRegistry.Transforms
+
+// squashSpaces trims s and replaces every run of white space by a single space.
+func squashSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// transformValues applies the transforms of r named by fieldType's options to a copy of values.
+func (r *Registry) transformValues(fieldType reflect.StructField, values []string) []string {
+	splits := strings.Split(fieldType.Tag.Get(tagNameIn), tagSep)
+	for _, option := range splits[1:] {
+		transform, ok := r.Transforms[strings.TrimSpace(option)]
+		if !ok {
+			continue
+		}
+
+		transformed := make([]string, len(values))
+		for i, v := range values {
+			transformed[i] = transform(v)
+		}
+		values = transformed
+	}
+
+	return values
+}
@@ -0,0 +1,217 @@
+package easybind
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// file options, e.g. `pos:"form:avatar,maxsize=10MB,mime=image/png|image/jpeg"`
+const (
+	optionMaxSize  = "maxsize"
+	optionMaxTotal = "maxtotal"
+	optionMIME     = "mime"
+	// optionFiles binds the files of any form key into a slice, e.g. `pos:"form:*,files"`
+	optionFiles = "files"
+)
+
+// DefaultMaxMemory bytes of the files of a multipart form kept in memory when Binder.MaxMemory is 0.
+const DefaultMaxMemory = 32 << 20
+
+var (
+	// ErrFileTooLarge an uploaded file is larger than its maxsize option.
+	ErrFileTooLarge = errors.New("file too large")
+	// ErrFileType the content of an uploaded file isn't one of the media types of its mime option.
+	ErrFileType = errors.New("file type not allowed")
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf(&multipart.FileHeader{})
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+)
+
+// isMultipart reports whether req has a multipart/form-data body.
+func isMultipart(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return strings.EqualFold(mediaType, mediaTypeMultipart)
+}
+
+// isFile reports whether typ is bound from the files of a multipart form.
+func isFile(typ reflect.Type) bool {
+	return typ == fileHeaderType || typ == fileHeadersType
+}
+
+// maxMemory returns the bytes of the files of a multipart form kept in memory.
+func (b *Binder) maxMemory() int64 {
+	if b.MaxMemory > 0 {
+		return b.MaxMemory
+	}
+
+	return DefaultMaxMemory
+}
+
+// removeFilesOnDone removes the temporary files of the multipart form of req once its context is done,
+// when its handler returns or its client disconnects, see Binder.RemoveFilesOnDone.
+func removeFilesOnDone(req *http.Request) {
+	form, done := req.MultipartForm, req.Context().Done()
+	if form == nil || done == nil {
+		return
+	}
+
+	go func() {
+		<-done
+		form.RemoveAll()
+	}()
+}
+
+// RemoveFiles removes the temporary files of the multipart form of req, spooled to disk as larger than
+// Binder.MaxMemory, e.g. once the files of a request cloned by a middleware, which http.Server doesn't remove,
+// are done with, whatever its handler returned.
+func RemoveFiles(req *http.Request) error {
+	if req.MultipartForm == nil {
+		return nil
+	}
+
+	return req.MultipartForm.RemoveAll()
+}
+
+// multipartFiles returns the files of the multipart form of the request body.
+func (e *easyReq) multipartFiles() map[string][]*multipart.FileHeader {
+	if _, err := e.postForm(); err != nil || e.req.MultipartForm == nil {
+		return nil
+	}
+
+	return e.req.MultipartForm.File
+}
+
+// bindFile binds field, a *multipart.FileHeader, from the file uploaded as name, or a []*multipart.FileHeader
+// from all of them, the files of every name, sorted, for *. They are checked against the maxsize, maxtotal
+// and mime options of fieldType.
+func (e *easyReq) bindFile(field reflect.Value, fieldType reflect.StructField, loc, name string, ft *FieldTrace) error {
+	var files []*multipart.FileHeader
+	if isWildcard(name) {
+		all := e.multipartFiles()
+		names := make([]string, 0, len(all))
+		for n := range all {
+			if strings.HasPrefix(n, strings.TrimSuffix(name, wildcard)) {
+				names = append(names, n)
+			}
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			files = append(files, all[n]...)
+		}
+	} else {
+		files = e.multipartFiles()[name]
+	}
+
+	if len(files) == 0 {
+		ft.Skipped = "no file"
+		return nil
+	}
+	if field.Type() == fileHeaderType {
+		files = files[:1]
+	}
+
+	var total int64
+	for _, fh := range files {
+		ft.Raw = append(ft.Raw, fh.Filename)
+		if err := checkFile(fieldType, fh); err != nil {
+			ft.Skipped = err.Error()
+			return &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: fh.Filename, Err: err}
+		}
+		total += fh.Size
+	}
+
+	if size, ok := getInTagOption(fieldType, optionMaxTotal); ok {
+		if max, _ := parseSize(size); total > max {
+			err := fmt.Errorf("%w: %d bytes in %d files, at most %s", ErrFileTooLarge, total, len(files), size)
+			ft.Skipped = err.Error()
+			return &BindError{Field: fieldType.Name, Source: loc, Name: name, Value: strings.Join(ft.Raw, tagSep), Err: err}
+		}
+	}
+
+	ft.Conversion = "file"
+	e.setField(fieldType.Name, true)
+	if field.Type() == fileHeaderType {
+		field.Set(reflect.ValueOf(files[0]))
+	} else {
+		field.Set(reflect.ValueOf(files))
+	}
+	return nil
+}
+
+// checkFile checks the size and the sniffed media type of fh against the maxsize and mime options of fieldType.
+func checkFile(fieldType reflect.StructField, fh *multipart.FileHeader) error {
+	if size, ok := getInTagOption(fieldType, optionMaxSize); ok {
+		if max, _ := parseSize(size); fh.Size > max {
+			return fmt.Errorf("%w: %d bytes, at most %s", ErrFileTooLarge, fh.Size, size)
+		}
+	}
+
+	allowed, ok := getInTagOption(fieldType, optionMIME)
+	if !ok {
+		return nil
+	}
+
+	mediaType, err := sniffFile(fh)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range strings.Split(allowed, optionFieldSep) {
+		if strings.EqualFold(a, mediaType) || strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*")) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrFileType, mediaType)
+}
+
+// sniffFile returns the media type of the content of fh, whatever the Content-Type the client sent.
+func sniffFile(fh *multipart.FileHeader) (string, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && n == 0 && fh.Size > 0 {
+		return "", err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return mediaType, nil
+}
+
+// parseSize parses a size in bytes, or in KB, MB or GB, binary multiples, e.g. 10MB.
+func parseSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	s = strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("malformed size %q", s)
+	}
+
+	return n * unit, nil
+}
@@ -0,0 +1,165 @@
+package easybind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type avatarArgs struct {
+	Name   string                `pos:"form:name"`
+	Avatar *multipart.FileHeader `pos:"form:avatar,maxsize=1KB,mime=image/png|image/gif"`
+	Notes  *multipart.FileHeader `pos:"form:notes,mime=text/*"`
+}
+
+func newUploadRequest(files map[string][]byte) *http.Request {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	w.WriteField("name", "bob")
+	for name, content := range files {
+		part, _ := w.CreateFormFile(name, name+".bin")
+		part.Write(content)
+	}
+	w.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/avatars", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestBindUpload(t *testing.T) {
+	assert.Nil(t, Register(&avatarArgs{}))
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+	a := avatarArgs{}
+	assert.Nil(t, Bind(newUploadRequest(map[string][]byte{"avatar": png, "notes": []byte("hello")}), &a))
+	assert.Equal(t, "bob", a.Name)
+	assert.Equal(t, "avatar.bin", a.Avatar.Filename)
+	assert.Equal(t, int64(len(png)), a.Avatar.Size)
+	assert.Equal(t, "notes.bin", a.Notes.Filename)
+
+	a = avatarArgs{}
+	assert.Nil(t, Bind(newUploadRequest(nil), &a))
+	assert.Nil(t, a.Avatar)
+
+	// the client's Content-Type doesn't matter, the content does
+	err := Bind(newUploadRequest(map[string][]byte{"avatar": []byte("<html><body>hi</body></html>")}), &avatarArgs{})
+	assert.True(t, errors.Is(err, ErrFileType))
+	var bindErr *BindError
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, "avatar.bin", bindErr.Value)
+
+	err = Bind(newUploadRequest(map[string][]byte{"avatar": append(png, make([]byte, 1024)...)}), &avatarArgs{})
+	assert.True(t, errors.Is(err, ErrFileTooLarge))
+
+	assert.NotNil(t, Register(&struct {
+		File *multipart.FileHeader `pos:"query:file"`
+	}{}))
+	assert.NotNil(t, Register(&struct {
+		File *multipart.FileHeader `pos:"form:file,maxsize=big"`
+	}{}))
+}
+
+type galleryArgs struct {
+	Photos []*multipart.FileHeader `pos:"form:photos,mime=image/*,maxtotal=2KB"`
+	All    []*multipart.FileHeader `pos:"form:*,files"`
+}
+
+func TestBindUploads(t *testing.T) {
+	assert.Nil(t, Register(&galleryArgs{}))
+
+	gif := []byte("GIF89a" + string(make([]byte, 300)))
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for _, name := range []string{"photos", "photos", "readme"} {
+		part, _ := w.CreateFormFile(name, name+".bin")
+		part.Write(gif)
+	}
+	w.Close()
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/galleries", bytes.NewReader(body.Bytes()))
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	a := galleryArgs{}
+	assert.Nil(t, Bind(req, &a))
+	assert.Len(t, a.Photos, 2)
+	assert.Len(t, a.All, 3)
+	assert.Equal(t, "readme.bin", a.All[2].Filename)
+
+	photos := map[string][]byte{"photos": append(gif, make([]byte, 800)...)}
+	assert.Nil(t, Bind(newUploadRequest(photos), &galleryArgs{}))
+
+	body.Reset()
+	w = multipart.NewWriter(body)
+	for i := 0; i < 2; i++ {
+		part, _ := w.CreateFormFile("photos", "big.gif")
+		part.Write(photos["photos"])
+	}
+	w.Close()
+	req, _ = http.NewRequest(http.MethodPost, "https://hello.world/galleries", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	assert.True(t, errors.Is(Bind(req, &galleryArgs{}), ErrFileTooLarge))
+
+	assert.NotNil(t, Register(&struct {
+		All []*multipart.FileHeader `pos:"form:*"`
+	}{}))
+	assert.NotNil(t, Register(&struct {
+		All *multipart.FileHeader `pos:"form:*,files"`
+	}{}))
+}
+
+type spoolKey struct{}
+
+func TestBindUploadSpooled(t *testing.T) {
+	b := &Binder{MaxMemory: 1 << 10, RemoveFilesOnDone: true}
+	spooled := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a clone, as by middlewares, isn't cleaned up by the server
+		r = r.WithContext(context.WithValue(r.Context(), spoolKey{}, true))
+		a := galleryArgs{}
+		assert.Nil(t, b.Bind(r, &a))
+		f, err := a.Photos[0].Open()
+		assert.Nil(t, err)
+		file, ok := f.(*os.File)
+		assert.True(t, ok)
+		spooled <- file.Name()
+		f.Close()
+	}))
+	defer srv.Close()
+
+	req := newUploadRequest(map[string][]byte{"photos": append([]byte("GIF89a"), make([]byte, 1500)...)})
+	resp, err := http.Post(srv.URL, req.Header.Get("Content-Type"), req.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	name := <-spooled
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(name)
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+
+	// kept once the context is done, until removed
+	ctx, cancel := context.WithCancel(context.Background())
+	req = newUploadRequest(map[string][]byte{"photos": append([]byte("GIF89a"), make([]byte, 1500)...)}).WithContext(ctx)
+	a := galleryArgs{}
+	assert.Nil(t, (&Binder{MaxMemory: 1 << 10}).Bind(req, &a))
+	assert.Len(t, a.Photos, 1)
+	f, err := a.Photos[0].Open()
+	assert.Nil(t, err)
+	name = f.(*os.File).Name()
+	f.Close()
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	_, err = os.Stat(name)
+	assert.Nil(t, err)
+	assert.Nil(t, RemoveFiles(req))
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+}
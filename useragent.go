@@ -0,0 +1,89 @@
+package easybind
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// devices of UserAgent
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+)
+
+// UserAgent User-Agent header parsed by UserAgentParser, bind with `pos:"header:User-Agent"`.
+type UserAgent struct {
+	Raw            string
+	Browser        string
+	BrowserVersion string
+	OS             string
+	OSVersion      string
+	// Device one of DeviceDesktop, DeviceMobile, DeviceTablet or DeviceBot
+	Device string
+}
+
+// UserAgentParser parses User-Agent headers into UserAgent, replace it to use another parser backend.
+var UserAgentParser = ParseUserAgent
+
+var (
+	uaBrowsers = []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/([\d.]+)`)},
+		{"Opera", regexp.MustCompile(`OPR/([\d.]+)`)},
+		{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+		{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+		{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+		{"IE", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)([\d.]+)`)},
+	}
+
+	uaOS = []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+		{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS ([\d_]+)`)},
+		{"Android", regexp.MustCompile(`Android ([\d.]+)`)},
+		{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+		{"Linux", regexp.MustCompile(`Linux()`)},
+	}
+
+	uaBot = regexp.MustCompile(`(?i)bot|crawler|spider|slurp`)
+)
+
+// ParseUserAgent default UserAgentParser, recognizes common browsers, operating systems and bots.
+func ParseUserAgent(raw string) UserAgent {
+	ua := UserAgent{Raw: raw, Device: DeviceDesktop}
+	for _, b := range uaBrowsers {
+		if m := b.re.FindStringSubmatch(raw); m != nil {
+			ua.Browser, ua.BrowserVersion = b.name, m[1]
+			break
+		}
+	}
+
+	for _, os := range uaOS {
+		if m := os.re.FindStringSubmatch(raw); m != nil {
+			ua.OS, ua.OSVersion = os.name, strings.ReplaceAll(m[1], "_", ".")
+			break
+		}
+	}
+
+	switch {
+	case uaBot.MatchString(raw):
+		ua.Device = DeviceBot
+	case strings.Contains(raw, "iPad") || ua.OS == "Android" && !strings.Contains(raw, "Mobile"):
+		ua.Device = DeviceTablet
+	case strings.Contains(raw, "Mobile") || strings.Contains(raw, "iPhone"):
+		ua.Device = DeviceMobile
+	}
+
+	return ua
+}
+
+func userAgentBinder(val string, typ reflect.Type) reflect.Value {
+	return reflect.ValueOf(UserAgentParser(val))
+}
@@ -0,0 +1,33 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindUserAgent(t *testing.T) {
+	type args struct {
+		UserAgent UserAgent `pos:"header:User-Agent"`
+	}
+
+	cases := []struct {
+		raw                                 string
+		browser, browserVer, os, osVer, dev string
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", "Chrome", "120.0.0.0", "Windows", "10.0", DeviceDesktop},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1", "Safari", "17.1", "iOS", "17.1", DeviceMobile},
+		{"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0 Safari/537.36 Edg/119.0", "Edge", "119.0", "Android", "13", DeviceTablet},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "", "", "", "", DeviceBot},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "https://hello.world/users", nil)
+		req.Header.Set("User-Agent", c.raw)
+
+		a := args{}
+		assert.Nil(t, Bind(req, &a))
+		assert.Equal(t, UserAgent{Raw: c.raw, Browser: c.browser, BrowserVersion: c.browserVer, OS: c.os, OSVersion: c.osVer, Device: c.dev}, a.UserAgent)
+	}
+}
@@ -0,0 +1,27 @@
+package easybind
+
+import (
+	"context"
+)
+
+// Validator implemented by params checking rules across fields, called after binding.
+type Validator interface {
+	Validate() error
+}
+
+// ContextValidator same as Validator, called with the request's context. Preferred over Validator.
+type ContextValidator interface {
+	ValidateContext(ctx context.Context) error
+}
+
+func validate(ctx context.Context, params interface{}) error {
+	if v, ok := params.(ContextValidator); ok {
+		return v.ValidateContext(ctx)
+	}
+
+	if v, ok := params.(Validator); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package easybind
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// BindMessage binds data, a json text message received on the websocket upgraded from req, into params:
+// its body fields from data, the others from req as when binding the upgrade request, e.g. a session cookie,
+// so services mixing REST and websocket endpoints share their params structs.
+//
+//	if err := easybind.Bind(req, &subscribeArgs); err != nil { ... }
+//	conn, _ := upgrader.Upgrade(w, req, nil)
+//	for {
+//		_, data, err := conn.ReadMessage()
+//		...
+//		var msg ChatMessage
+//		if err := easybind.BindMessage(req, data, &msg); err != nil { ... }
+//	}
+func BindMessage(req *http.Request, data []byte, params interface{}, pathQueryier ...interface{}) error {
+	return DefaultBinder.BindMessage(req, data, params, pathQueryier...)
+}
+
+// BindMessage same as BindMessage, by the configuration of b.
+func (b *Binder) BindMessage(req *http.Request, data []byte, params interface{}, pathQueryier ...interface{}) error {
+	return b.Bind(messageRequest(req, data), params, pathQueryier...)
+}
+
+// messageRequest returns a copy of req, the upgrade request of a websocket, with the json message data as body,
+// read whatever the BodyPolicy of its method.
+func messageRequest(req *http.Request, data []byte) *http.Request {
+	msg := req.Clone(WithBodyPolicy(req.Context(), BodyAllowed))
+	msg.Body = io.NopCloser(bytes.NewReader(data))
+	msg.ContentLength = int64(len(data))
+	msg.Header.Set("Content-Type", mediaTypeJSON)
+	return msg
+}
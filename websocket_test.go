@@ -0,0 +1,29 @@
+package easybind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type chatMessage struct {
+	Room    string `pos:"query:room,required"`
+	Session string `pos:"cookie:session"`
+	Text    string `json:"text" pos:"body,required"`
+}
+
+func TestBindMessage(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://hello.world/ws?room=go", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+
+	b := &Binder{BodyPolicies: map[string]BodyPolicy{http.MethodGet: BodyRejected}, StrictContentType: true}
+	msg := chatMessage{}
+	assert.Nil(t, b.BindMessage(req, []byte(`{"text": "hi"}`), &msg))
+	assert.Equal(t, chatMessage{Room: "go", Session: "s", Text: "hi"}, msg)
+
+	assert.ErrorIs(t, b.BindMessage(req, []byte(`{}`), &chatMessage{}), ErrRequired)
+	assert.NotNil(t, b.BindMessage(req, []byte(`{"text": `), &chatMessage{}))
+	assert.Nil(t, req.Body)
+}
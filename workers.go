@@ -0,0 +1,90 @@
+package easybind
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// run runs the jobs binding the fields of a struct concurrently and returns the error of the first failing one,
+// in the order of jobs, so the same request fails the same way. With Binder.Workers, at most as many run
+// at once for the whole binding, a job runs in the calling goroutine while all the workers are busy,
+// so nested structs don't wait for workers held by their parents.
+func (e *easyReq) run(jobs []func() error) error {
+	var (
+		wg   = sync.WaitGroup{}
+		errs = make([]error, len(jobs))
+	)
+
+	for i, job := range jobs {
+		if e.workers == nil {
+			wg.Add(1)
+			go func(i int, job func() error) {
+				defer wg.Done()
+				errs[i] = job()
+			}(i, job)
+			continue
+		}
+
+		select {
+		case e.workers <- struct{}{}:
+			wg.Add(1)
+			go func(i int, job func() error) {
+				defer func() {
+					<-e.workers
+					wg.Done()
+				}()
+				errs[i] = job()
+			}(i, job)
+		default:
+			errs[i] = job()
+		}
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prefetch reads the json body of a request while its fields and files are bound, see Binder.Workers.
+type prefetch struct {
+	body io.ReadCloser
+	data []byte
+	err  error
+	done chan struct{}
+}
+
+// prefetchBody starts reading the body of req, unless it's a form read by the form fields.
+// Only the body is read, the request isn't written while its fields are bound.
+func (b *Binder) prefetchBody(req *http.Request) *prefetch {
+	if isFormBody(req) {
+		return nil
+	}
+
+	p := &prefetch{body: req.Body, done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		p.data, p.err = b.readAll(p.body)
+	}()
+
+	return p
+}
+
+// wait returns the body once read.
+func (p *prefetch) wait() ([]byte, error) {
+	<-p.done
+	return p.data, p.err
+}
+
+// abort gives up the body: it's closed, so the read ends with the read in progress rather than once the client
+// sent it all, and abort returns once it did, the prefetch outliving no binding. The body isn't restored then,
+// whatever Binder.PreserveBody.
+func (p *prefetch) abort() {
+	p.body.Close()
+	<-p.done
+}
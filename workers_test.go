@@ -0,0 +1,104 @@
+package easybind
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pooledArgs struct {
+	A    int      `pos:"query:a"`
+	B    string   `pos:"header:X-B"`
+	C    []int    `pos:"query:c"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+	Page struct {
+		Size int `pos:"query:size"`
+	}
+}
+
+func TestBindWorkers(t *testing.T) {
+	b := &Binder{Workers: 2, PreserveBody: true}
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?a=1&c=2&c=3&size=10", strings.NewReader(`{"name": "bob", "tags": ["x"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-B", "b")
+
+	a := pooledArgs{}
+	assert.Nil(t, b.Bind(req, &a))
+	assert.Equal(t, 1, a.A)
+	assert.Equal(t, "b", a.B)
+	assert.Equal(t, []int{2, 3}, a.C)
+	assert.Equal(t, "bob", a.Name)
+	assert.Equal(t, []string{"x"}, a.Tags)
+	assert.Equal(t, 10, a.Page.Size)
+
+	type failing struct {
+		Z [2]int `pos:"query:z"`
+		Y [2]int `pos:"query:y"`
+		X [2]int `pos:"query:x"`
+	}
+	// the first failing field in declaration order, with or without workers
+	for _, b := range []*Binder{{Workers: 3}, {}} {
+		for i := 0; i < 20; i++ {
+			req, _ = http.NewRequest(http.MethodGet, "https://hello.world/points?x=1&y=1&z=1", nil)
+			var bindErr *BindError
+			assert.True(t, errors.As(b.Bind(req, &failing{}), &bindErr))
+			assert.Equal(t, "Z", bindErr.Field)
+		}
+	}
+}
+
+type tenantSource struct{}
+
+func (tenantSource) Name() string { return "tenant" }
+
+func (tenantSource) Values(req *http.Request, name string) ([]string, error) {
+	return req.Header.Values("X-Tenant-" + name), nil
+}
+
+func TestBindWorkersBody(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterSource(tenantSource{})
+	b := &Binder{Workers: 4, PreserveBody: true, Registry: r}
+
+	type args struct {
+		Tenant string `pos:"tenant:id"`
+		Page   int    `pos:"query:page"`
+		Point  [2]int `pos:"query:point"`
+		Name   string `json:"name"`
+	}
+	for i := 0; i < 20; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?page=2", strings.NewReader(`{"name": "bob"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant-id", "acme")
+		a := args{}
+		assert.Nil(t, b.Bind(req, &a))
+		assert.Equal(t, args{Tenant: "acme", Page: 2, Name: "bob"}, a)
+		data, _ := io.ReadAll(req.Body)
+		assert.Equal(t, `{"name": "bob"}`, string(data))
+	}
+
+	// a failed field doesn't wait for the body of a slow client
+	body, w := io.Pipe()
+	defer w.Close()
+	req, _ := http.NewRequest(http.MethodPost, "https://hello.world/users?point=1", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = 1 << 10
+	done := make(chan error, 1)
+	go func() { done <- b.Bind(req, &args{}) }()
+	select {
+	case err := <-done:
+		assert.NotNil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("binding waits for the body")
+	}
+
+	// the body was closed and its read ended before Bind returned
+	_, err := w.Write([]byte("{"))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+}